@@ -1,26 +1,70 @@
 package async
 
+import (
+	"context"
+	"errors"
+)
+
+// ErrAlreadyAwaited is returned when a result channel has already been
+// read and closed. Wrap the task in a Future to await it more than once.
+var ErrAlreadyAwaited = errors.New("async: result already awaited")
+
+// ErrNoTasks is returned by AwaitAny and Race when called without channels.
+var ErrNoTasks = errors.New("async: no tasks to await")
+
 type Result[T any] struct {
 	Data T
 	Err  error
 }
 
+// recv reads a task's result, reporting ErrAlreadyAwaited instead of the
+// zero value a drained, closed channel would yield.
+func recv[T any](res Result[T], ok bool) Result[T] {
+	if !ok {
+		return Result[T]{Err: ErrAlreadyAwaited}
+	}
+	return res
+}
+
 func Await[T any](ch <-chan Result[T]) (T, error) {
-	res := <-ch
+	res, ok := <-ch
+	res = recv(res, ok)
 	return res.Data, res.Err
 }
 
-func AwaitAll[T any](chs ...<-chan Result[T]) ([]T, error) {
-	type pair struct {
-		i int
-		r Result[T]
+// AwaitCtx is Await that gives up with ctx.Err() once ctx is done. The task
+// itself keeps running; pass the same ctx to GoCtx to stop it as well.
+func AwaitCtx[T any](ctx context.Context, ch <-chan Result[T]) (T, error) {
+	select {
+	case res, ok := <-ch:
+		res = recv(res, ok)
+		return res.Data, res.Err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
 	}
+}
 
-	out := make(chan pair, len(chs))
+type settled[T any] struct {
+	i int
+	r Result[T]
+}
+
+// fanIn delivers every channel's result as it settles. The output is sized
+// so forwarders never block, even if the caller stops reading early.
+func fanIn[T any](chs []<-chan Result[T]) <-chan settled[T] {
+	out := make(chan settled[T], len(chs))
 	for i, ch := range chs {
-		i, ch := i, ch // capture copy
-		go func() { out <- pair{i, <-ch} }()
+		go func() {
+			res, ok := <-ch
+			out <- settled[T]{i, recv(res, ok)}
+		}()
 	}
+	return out
+}
+
+func AwaitAll[T any](chs ...<-chan Result[T]) ([]T, error) {
+	out := fanIn(chs)
 
 	results := make([]T, len(chs))
 	var firstErr error
@@ -34,6 +78,125 @@ func AwaitAll[T any](chs ...<-chan Result[T]) ([]T, error) {
 	return results, firstErr
 }
 
+// AwaitAllFailFast returns as soon as any task fails, calling cancel so the
+// remaining tasks (started with GoCtx on the same context) can stop early.
+// cancel may be nil.
+func AwaitAllFailFast[T any](cancel context.CancelFunc, chs ...<-chan Result[T]) ([]T, error) {
+	out := fanIn(chs)
+
+	results := make([]T, len(chs))
+	for range chs {
+		p := <-out
+		if p.r.Err != nil {
+			if cancel != nil {
+				cancel()
+			}
+			return nil, p.r.Err
+		}
+		results[p.i] = p.r.Data
+	}
+	return results, nil
+}
+
+// AwaitAllErrors waits for every task and joins all of their errors, in
+// input order, with errors.Join.
+func AwaitAllErrors[T any](chs ...<-chan Result[T]) ([]T, error) {
+	out := fanIn(chs)
+
+	results := make([]T, len(chs))
+	errs := make([]error, len(chs))
+	for range chs {
+		p := <-out
+		results[p.i], errs[p.i] = p.r.Data, p.r.Err
+	}
+	return results, errors.Join(errs...)
+}
+
+// AwaitAllSettled waits for every task and returns each outcome, success
+// or failure, in input order.
+func AwaitAllSettled[T any](chs ...<-chan Result[T]) []Result[T] {
+	out := fanIn(chs)
+
+	results := make([]Result[T], len(chs))
+	for range chs {
+		p := <-out
+		results[p.i] = p.r
+	}
+	return results
+}
+
+// Indexed pairs a value with its position in the original input.
+type Indexed[T any] struct {
+	Index int
+	Value T
+}
+
+// Split separates settled results into successes and failures, keeping
+// each one's input index.
+func Split[T any](rs []Result[T]) (ok []Indexed[T], failed []Indexed[error]) {
+	for i, r := range rs {
+		if r.Err != nil {
+			failed = append(failed, Indexed[error]{Index: i, Value: r.Err})
+			continue
+		}
+		ok = append(ok, Indexed[T]{Index: i, Value: r.Data})
+	}
+	return ok, failed
+}
+
+// AwaitAny returns the first successful result. If every task fails, the
+// errors are joined in input order. Once a winner is known cancel (if not
+// nil) is called so the losers can stop; their results are discarded.
+func AwaitAny[T any](cancel context.CancelFunc, chs ...<-chan Result[T]) (T, error) {
+	var zero T
+	if len(chs) == 0 {
+		return zero, ErrNoTasks
+	}
+	out := fanIn(chs)
+
+	errs := make([]error, len(chs))
+	for range chs {
+		p := <-out
+		if p.r.Err == nil {
+			if cancel != nil {
+				cancel()
+			}
+			return p.r.Data, nil
+		}
+		errs[p.i] = p.r.Err
+	}
+	return zero, errors.Join(errs...)
+}
+
+// Race returns the first result to settle, success or failure, and calls
+// cancel (if not nil) so the others can stop.
+func Race[T any](cancel context.CancelFunc, chs ...<-chan Result[T]) (T, error) {
+	if len(chs) == 0 {
+		var zero T
+		return zero, ErrNoTasks
+	}
+
+	p := <-fanIn(chs)
+	if cancel != nil {
+		cancel()
+	}
+	return p.r.Data, p.r.Err
+}
+
+// GoAll runs every fn concurrently on a context derived from ctx and
+// returns their results in order. The first failure cancels the others and
+// is returned, like errgroup.
+func GoAll[T any](ctx context.Context, fns ...func(context.Context) (T, error)) ([]T, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	chs := make([]<-chan Result[T], len(fns))
+	for i, fn := range fns {
+		chs[i] = GoCtx(ctx, fn)
+	}
+	return AwaitAllFailFast(cancel, chs...)
+}
+
 func Go[T any](fn func() (T, error)) <-chan Result[T] {
 	ch := make(chan Result[T], 1) // buffered so sender never blocks
 	go func() {
@@ -43,3 +206,19 @@ func Go[T any](fn func() (T, error)) <-chan Result[T] {
 	}()
 	return ch
 }
+
+// GoCtx runs fn with ctx. If ctx is already done, fn is skipped and the
+// result carries ctx.Err().
+func GoCtx[T any](ctx context.Context, fn func(context.Context) (T, error)) <-chan Result[T] {
+	ch := make(chan Result[T], 1)
+	go func() {
+		defer close(ch)
+		if err := ctx.Err(); err != nil {
+			ch <- Result[T]{Err: err}
+			return
+		}
+		v, err := fn(ctx)
+		ch <- Result[T]{Data: v, Err: err}
+	}()
+	return ch
+}
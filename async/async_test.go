@@ -1,9 +1,11 @@
 package async
 
 import (
+	"context"
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 )
 
 // helper to quickly build a ready channel
@@ -73,3 +75,212 @@ func TestAwaitAllWithUnbuffered(t *testing.T) {
 		t.Fatal("expected error but got nil")
 	}
 }
+
+func TestGoCtxPassesContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := GoCtx(ctx, func(ctx context.Context) (int, error) {
+		<-ctx.Done() // a long task that honours cancellation
+		return 0, ctx.Err()
+	})
+	cancel()
+
+	if _, err := Await(ch); !errors.Is(err, context.Canceled) {
+		t.Fatalf("want context.Canceled, got %v", err)
+	}
+}
+
+func TestGoCtxSkipsWhenAlreadyCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	ran := false
+	_, err := Await(GoCtx(ctx, func(context.Context) (int, error) { ran = true; return 1, nil }))
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("want context.Canceled, got %v", err)
+	}
+	if ran {
+		t.Fatal("fn should not run on a cancelled context")
+	}
+}
+
+func TestAwaitCtx(t *testing.T) {
+	got, err := AwaitCtx(context.Background(), ready(5, nil))
+	if err != nil || got != 5 {
+		t.Fatalf("want 5, nil; got %d, %v", got, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	never := make(chan Result[int])
+	if _, err := AwaitCtx(ctx, never); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("want context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestAwaitAllFailFastReturnsFirstFailure(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	e := errors.New("boom")
+	started, stopped := make(chan struct{}), make(chan error, 1)
+	slow := GoCtx(ctx, func(ctx context.Context) (int, error) {
+		close(started)
+		<-ctx.Done() // only finishes once the sibling failure cancels it
+		stopped <- ctx.Err()
+		return 0, ctx.Err()
+	})
+	bad := ready(0, e)
+	<-started
+
+	_, err := AwaitAllFailFast(cancel, slow, bad)
+	if !errors.Is(err, e) {
+		t.Fatalf("want %v, got %v", e, err)
+	}
+	if err := <-stopped; !errors.Is(err, context.Canceled) {
+		t.Fatalf("sibling should be cancelled, got %v", err)
+	}
+}
+
+func TestAwaitAllFailFastSuccess(t *testing.T) {
+	got, err := AwaitAllFailFast(nil, ready(1, nil), ready(2, nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Fatalf("want [1 2], got %v", got)
+	}
+}
+
+func TestAwaitAllErrorsJoinsEveryError(t *testing.T) {
+	e1, e2 := errors.New("one"), errors.New("two")
+
+	got, err := AwaitAllErrors(ready(0, e1), ready(7, nil), ready(0, e2))
+	if !errors.Is(err, e1) || !errors.Is(err, e2) {
+		t.Fatalf("want both errors, got %v", err)
+	}
+	if err.Error() != "one\ntwo" {
+		t.Fatalf("errors should be joined in input order, got %q", err)
+	}
+	if got[1] != 7 {
+		t.Fatalf("successful results are kept, got %v", got)
+	}
+}
+
+func TestGoAll(t *testing.T) {
+	got, err := GoAll(context.Background(),
+		func(context.Context) (string, error) { return "a", nil },
+		func(context.Context) (string, error) { return "b", nil },
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Fatalf("want [a b], got %v", got)
+	}
+
+	e := errors.New("boom")
+	_, err = GoAll(context.Background(),
+		func(ctx context.Context) (string, error) { <-ctx.Done(); return "", ctx.Err() },
+		func(context.Context) (string, error) { return "", e },
+	)
+	if !errors.Is(err, e) {
+		t.Fatalf("want %v, got %v", e, err)
+	}
+}
+
+func TestAwaitAllSettled(t *testing.T) {
+	e := errors.New("replica down")
+	slow := Go(func() (string, error) { time.Sleep(5 * time.Millisecond); return "r0", nil })
+
+	got := AwaitAllSettled(slow, ready("", e), ready("r2", nil))
+	want := []Result[string]{{Data: "r0"}, {Err: e}, {Data: "r2"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+
+	ok, failed := Split(got)
+	wantOK := []Indexed[string]{{Index: 0, Value: "r0"}, {Index: 2, Value: "r2"}}
+	if !reflect.DeepEqual(ok, wantOK) {
+		t.Fatalf("want %v, got %v", wantOK, ok)
+	}
+	if len(failed) != 1 || failed[0].Index != 1 || !errors.Is(failed[0].Value, e) {
+		t.Fatalf("want replica 1 failed, got %v", failed)
+	}
+}
+
+// blocked starts a task that only finishes when ctx is cancelled, and
+// reports that it was through stopped.
+func blocked(ctx context.Context, stopped chan<- error) <-chan Result[string] {
+	started := make(chan struct{})
+	ch := GoCtx(ctx, func(ctx context.Context) (string, error) {
+		close(started)
+		<-ctx.Done()
+		stopped <- ctx.Err()
+		return "", ctx.Err()
+	})
+	<-started
+	return ch
+}
+
+func TestAwaitAnyFirstSuccessCancelsLosers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stopped := make(chan error, 1)
+
+	got, err := AwaitAny(cancel, ready("", errors.New("primary down")), blocked(ctx, stopped), ready("backup", nil))
+	if err != nil || got != "backup" {
+		t.Fatalf("want backup, nil; got %q, %v", got, err)
+	}
+	if err := <-stopped; !errors.Is(err, context.Canceled) {
+		t.Fatalf("loser should be cancelled, got %v", err)
+	}
+}
+
+func TestAwaitAnyAllFail(t *testing.T) {
+	e1, e2 := errors.New("one"), errors.New("two")
+
+	_, err := AwaitAny(nil, ready(0, e1), ready(0, e2))
+	if !errors.Is(err, e1) || !errors.Is(err, e2) {
+		t.Fatalf("want both errors, got %v", err)
+	}
+
+	if _, err := AwaitAny[int](nil); !errors.Is(err, ErrNoTasks) {
+		t.Fatalf("want ErrNoTasks, got %v", err)
+	}
+}
+
+func TestRaceReturnsFirstSettled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stopped := make(chan error, 1)
+	e := errors.New("fast failure")
+
+	_, err := Race(cancel, blocked(ctx, stopped), ready("", e))
+	if !errors.Is(err, e) {
+		t.Fatalf("a failure can win the race, got %v", err)
+	}
+	if err := <-stopped; !errors.Is(err, context.Canceled) {
+		t.Fatalf("loser should be cancelled, got %v", err)
+	}
+
+	if _, err := Race[int](nil); !errors.Is(err, ErrNoTasks) {
+		t.Fatalf("want ErrNoTasks, got %v", err)
+	}
+}
+
+func TestAwaitTwiceReportsAlreadyAwaited(t *testing.T) {
+	ch := Go(func() (int, error) { return 7, nil })
+	if got, err := Await(ch); err != nil || got != 7 {
+		t.Fatalf("want 7, nil; got %d, %v", got, err)
+	}
+
+	if _, err := Await(ch); !errors.Is(err, ErrAlreadyAwaited) {
+		t.Fatalf("want ErrAlreadyAwaited, got %v", err)
+	}
+	if _, err := AwaitCtx(context.Background(), ch); !errors.Is(err, ErrAlreadyAwaited) {
+		t.Fatalf("want ErrAlreadyAwaited, got %v", err)
+	}
+	if _, err := AwaitAll(ch); !errors.Is(err, ErrAlreadyAwaited) {
+		t.Fatalf("want ErrAlreadyAwaited, got %v", err)
+	}
+}
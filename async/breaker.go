@@ -0,0 +1,246 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by Execute while the breaker rejects calls.
+var ErrCircuitOpen = errors.New("async: circuit breaker is open")
+
+// Clock supplies the current time to a CircuitBreaker, so tests can move
+// time forward instead of sleeping. SystemClock is the default.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the real wall clock.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// BreakerState is the state of a CircuitBreaker.
+type BreakerState int
+
+const (
+	StateClosed   BreakerState = iota // calls flow; outcomes are recorded
+	StateOpen                         // calls fail fast with ErrCircuitOpen
+	StateHalfOpen                     // a few probe calls test the dependency
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// Outcome is how a call's result counts towards a CircuitBreaker.
+type Outcome int
+
+const (
+	OutcomeSuccess Outcome = iota // the dependency answered
+	OutcomeFailure                // the dependency failed
+	OutcomeIgnored                // says nothing about the dependency, e.g. the caller gave up
+)
+
+// BreakerConfig configures a CircuitBreaker. Zero fields take the
+// documented defaults.
+type BreakerConfig struct {
+	WindowSize     int           // outcomes kept in the rolling window; default 20
+	FailureRate    float64       // failure ratio in the window that opens the circuit; default 0.5
+	MinRequests    int           // outcomes needed before the rate is judged; default 10
+	CoolDown       time.Duration // time spent open before probing; default 30s
+	HalfOpenProbes int           // probe calls allowed, all must succeed to close; default 1
+
+	// Classify decides how a call's error counts. The default treats nil as
+	// a success, context.Canceled as ignored and any other error as a failure.
+	Classify      func(error) Outcome
+	OnStateChange func(from, to BreakerState)
+	Clock         Clock
+}
+
+func (c BreakerConfig) withDefaults() BreakerConfig {
+	if c.WindowSize <= 0 {
+		c.WindowSize = 20
+	}
+	if c.FailureRate <= 0 {
+		c.FailureRate = 0.5
+	}
+	if c.MinRequests <= 0 {
+		c.MinRequests = min(10, c.WindowSize)
+	}
+	if c.CoolDown <= 0 {
+		c.CoolDown = 30 * time.Second
+	}
+	if c.HalfOpenProbes <= 0 {
+		c.HalfOpenProbes = 1
+	}
+	if c.Classify == nil {
+		c.Classify = classify
+	}
+	if c.Clock == nil {
+		c.Clock = SystemClock
+	}
+	return c
+}
+
+func classify(err error) Outcome {
+	switch {
+	case err == nil:
+		return OutcomeSuccess
+	case errors.Is(err, context.Canceled):
+		return OutcomeIgnored
+	default:
+		return OutcomeFailure
+	}
+}
+
+// CircuitBreaker stops calling a failing dependency for a while. It opens
+// when the failure rate over the last WindowSize calls reaches FailureRate,
+// rejects calls for CoolDown, then lets HalfOpenProbes calls through: if
+// they all succeed it closes again, otherwise it reopens.
+type CircuitBreaker struct {
+	cfg BreakerConfig
+
+	mu         sync.Mutex
+	state      BreakerState
+	generation uint64 // bumped on every transition, so stale outcomes are ignored
+	openedAt   time.Time
+
+	outcomes []bool // ring of recent results, true = failure
+	next     int
+	count    int
+	failures int
+
+	probes    int // probe calls admitted while half-open
+	successes int // probe calls that succeeded
+}
+
+// NewCircuitBreaker returns a closed breaker.
+func NewCircuitBreaker(cfg BreakerConfig) *CircuitBreaker {
+	cfg = cfg.withDefaults()
+	return &CircuitBreaker{cfg: cfg, outcomes: make([]bool, cfg.WindowSize)}
+}
+
+// State reports the breaker's current state.
+func (cb *CircuitBreaker) State() BreakerState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.coolDown()
+	return cb.state
+}
+
+// Execute runs fn through cb. While the circuit is open fn is not called
+// and the result carries ErrCircuitOpen. A panic in fn counts as a failure
+// and is passed on to the caller.
+func Execute[T any](ctx context.Context, cb *CircuitBreaker, fn func(context.Context) (T, error)) Result[T] {
+	gen, err := cb.allow()
+	if err != nil {
+		return Result[T]{Err: err}
+	}
+
+	outcome := OutcomeFailure // stays so if fn panics
+	defer func() { cb.record(gen, outcome) }()
+
+	v, err := fn(ctx)
+	outcome = cb.cfg.Classify(err)
+	return Result[T]{Data: v, Err: err}
+}
+
+// allow admits a call, returning the generation it belongs to.
+func (cb *CircuitBreaker) allow() (uint64, error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.coolDown()
+
+	switch cb.state {
+	case StateOpen:
+		return 0, ErrCircuitOpen
+	case StateHalfOpen:
+		if cb.probes >= cb.cfg.HalfOpenProbes {
+			return 0, ErrCircuitOpen
+		}
+		cb.probes++
+	}
+	return cb.generation, nil
+}
+
+func (cb *CircuitBreaker) record(gen uint64, outcome Outcome) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if gen != cb.generation {
+		return // admitted before the last transition
+	}
+
+	if outcome == OutcomeIgnored {
+		if cb.state == StateHalfOpen {
+			cb.probes-- // free the slot for another probe
+		}
+		return
+	}
+	failed := outcome == OutcomeFailure
+
+	switch cb.state {
+	case StateClosed:
+		if cb.count == len(cb.outcomes) && cb.outcomes[cb.next] {
+			cb.failures-- // evict the oldest outcome
+		}
+		cb.outcomes[cb.next] = failed
+		cb.next = (cb.next + 1) % len(cb.outcomes)
+		cb.count = min(cb.count+1, len(cb.outcomes))
+		if failed {
+			cb.failures++
+		}
+		if cb.count >= cb.cfg.MinRequests && float64(cb.failures)/float64(cb.count) >= cb.cfg.FailureRate {
+			cb.transition(StateOpen)
+		}
+
+	case StateHalfOpen:
+		if failed {
+			cb.transition(StateOpen)
+			return
+		}
+		if cb.successes++; cb.successes >= cb.cfg.HalfOpenProbes {
+			cb.transition(StateClosed)
+		}
+	}
+}
+
+// coolDown moves an open breaker to half-open once CoolDown has passed.
+// Callers hold cb.mu.
+func (cb *CircuitBreaker) coolDown() {
+	if cb.state == StateOpen && !cb.cfg.Clock.Now().Before(cb.openedAt.Add(cb.cfg.CoolDown)) {
+		cb.transition(StateHalfOpen)
+	}
+}
+
+// transition resets the counters for the new state. Callers hold cb.mu;
+// OnStateChange runs under it, so it must not call back into cb.
+func (cb *CircuitBreaker) transition(to BreakerState) {
+	from := cb.state
+	cb.state = to
+	cb.generation++
+	cb.probes, cb.successes = 0, 0
+
+	switch to {
+	case StateOpen:
+		cb.openedAt = cb.cfg.Clock.Now()
+	case StateClosed:
+		clear(cb.outcomes)
+		cb.next, cb.count, cb.failures = 0, 0, 0
+	}
+
+	if cb.cfg.OnStateChange != nil {
+		cb.cfg.OnStateChange(from, to)
+	}
+}
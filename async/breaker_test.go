@@ -0,0 +1,215 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// manualClock is a Clock that only moves when told to.
+type manualClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *manualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+var errDown = errors.New("dependency down")
+
+func call(cb *CircuitBreaker, err error) error {
+	return Execute(context.Background(), cb, func(context.Context) (int, error) { return 1, err }).Err
+}
+
+func TestBreakerOpensOnFailureRate(t *testing.T) {
+	cb := NewCircuitBreaker(BreakerConfig{WindowSize: 4, MinRequests: 4, FailureRate: 0.5})
+
+	call(cb, nil)
+	call(cb, errDown)
+	call(cb, nil)
+	if cb.State() != StateClosed {
+		t.Fatal("should stay closed below MinRequests")
+	}
+
+	call(cb, errDown) // 2 of 4 failed
+	if cb.State() != StateOpen {
+		t.Fatalf("want open, got %v", cb.State())
+	}
+
+	called := false
+	res := Execute(context.Background(), cb, func(context.Context) (int, error) { called = true; return 1, nil })
+	if !errors.Is(res.Err, ErrCircuitOpen) || called {
+		t.Fatalf("open breaker should reject without calling; got %v, called=%v", res.Err, called)
+	}
+}
+
+func TestBreakerRollingWindow(t *testing.T) {
+	cb := NewCircuitBreaker(BreakerConfig{WindowSize: 4, MinRequests: 4, FailureRate: 0.75})
+
+	// old failures slide out of the window before the rate is reached
+	for _, err := range []error{errDown, errDown, nil, nil, nil, errDown, errDown} {
+		call(cb, err)
+	}
+	if cb.State() != StateClosed {
+		t.Fatalf("window holds 2 of 4 failures, want closed, got %v", cb.State())
+	}
+
+	call(cb, errDown)
+	if cb.State() != StateOpen {
+		t.Fatalf("window holds 3 of 4 failures, want open, got %v", cb.State())
+	}
+}
+
+func TestBreakerHalfOpenRecovery(t *testing.T) {
+	clock := &manualClock{now: time.Unix(0, 0)}
+	var transitions []string
+	cb := NewCircuitBreaker(BreakerConfig{
+		WindowSize:     2,
+		MinRequests:    2,
+		CoolDown:       time.Minute,
+		HalfOpenProbes: 2,
+		Clock:          clock,
+		OnStateChange: func(from, to BreakerState) {
+			transitions = append(transitions, from.String()+"→"+to.String())
+		},
+	})
+
+	call(cb, errDown)
+	call(cb, errDown)
+	clock.Advance(59 * time.Second)
+	if cb.State() != StateOpen {
+		t.Fatal("should stay open during cool-down")
+	}
+
+	clock.Advance(time.Second)
+	if cb.State() != StateHalfOpen {
+		t.Fatalf("want half-open after cool-down, got %v", cb.State())
+	}
+	if err := call(cb, errDown); !errors.Is(err, errDown) {
+		t.Fatalf("probe should run, got %v", err)
+	}
+	if cb.State() != StateOpen {
+		t.Fatal("failed probe should reopen")
+	}
+
+	clock.Advance(time.Minute)
+	call(cb, nil)
+	if cb.State() != StateHalfOpen {
+		t.Fatal("one successful probe of two keeps it half-open")
+	}
+	call(cb, nil)
+	if cb.State() != StateClosed {
+		t.Fatalf("want closed after successful probes, got %v", cb.State())
+	}
+
+	want := []string{"closed→open", "open→half-open", "half-open→open", "open→half-open", "half-open→closed"}
+	if !reflect.DeepEqual(transitions, want) {
+		t.Fatalf("want %v, got %v", want, transitions)
+	}
+}
+
+func TestBreakerLimitsHalfOpenProbes(t *testing.T) {
+	clock := &manualClock{now: time.Unix(0, 0)}
+	cb := NewCircuitBreaker(BreakerConfig{WindowSize: 1, MinRequests: 1, CoolDown: time.Second, Clock: clock})
+
+	call(cb, errDown)
+	clock.Advance(time.Second)
+
+	release := make(chan struct{})
+	probe := Go(func() (int, error) {
+		r := Execute(context.Background(), cb, func(context.Context) (int, error) { <-release; return 1, nil })
+		return r.Data, r.Err
+	})
+	for {
+		cb.mu.Lock()
+		admitted := cb.probes
+		cb.mu.Unlock()
+		if admitted == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := call(cb, nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("a second concurrent probe should be rejected, got %v", err)
+	}
+	close(release)
+	if _, err := Await(probe); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cb.State() != StateClosed {
+		t.Fatalf("want closed, got %v", cb.State())
+	}
+}
+
+func TestBreakerIgnoresCancellation(t *testing.T) {
+	clock := &manualClock{now: time.Unix(0, 0)}
+	cb := NewCircuitBreaker(BreakerConfig{WindowSize: 2, MinRequests: 2, CoolDown: time.Second, Clock: clock})
+
+	// cancellations neither trip nor dilute the window
+	call(cb, context.Canceled)
+	call(cb, errDown)
+	call(cb, context.Canceled)
+	if cb.State() != StateClosed {
+		t.Fatal("one counted failure is below MinRequests")
+	}
+	call(cb, errDown)
+	if cb.State() != StateOpen {
+		t.Fatalf("want open after 2 of 2 counted failures, got %v", cb.State())
+	}
+
+	// a cancelled probe proves nothing: it frees its slot and stays half-open
+	clock.Advance(time.Second)
+	if err := call(cb, context.Canceled); !errors.Is(err, context.Canceled) {
+		t.Fatalf("probe should run, got %v", err)
+	}
+	if cb.State() != StateHalfOpen {
+		t.Fatalf("want half-open after a cancelled probe, got %v", cb.State())
+	}
+	if err := call(cb, nil); err != nil {
+		t.Fatalf("the freed slot should admit another probe, got %v", err)
+	}
+	if cb.State() != StateClosed {
+		t.Fatalf("want closed after a real successful probe, got %v", cb.State())
+	}
+}
+
+func TestBreakerCountsPanicAsFailure(t *testing.T) {
+	clock := &manualClock{now: time.Unix(0, 0)}
+	cb := NewCircuitBreaker(BreakerConfig{WindowSize: 1, MinRequests: 1, CoolDown: time.Second, Clock: clock})
+
+	call(cb, errDown)
+	clock.Advance(time.Second)
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("the panic should reach the caller")
+			}
+		}()
+		Execute(context.Background(), cb, func(context.Context) (int, error) { panic("probe crashed") })
+	}()
+	if cb.State() != StateOpen {
+		t.Fatalf("a panicking probe should reopen, got %v", cb.State())
+	}
+
+	clock.Advance(time.Second)
+	if err := call(cb, nil); err != nil {
+		t.Fatalf("the breaker should probe again after cool-down, got %v", err)
+	}
+	if cb.State() != StateClosed {
+		t.Fatalf("want closed, got %v", cb.State())
+	}
+}
@@ -0,0 +1,120 @@
+package async
+
+import "context"
+
+// Future is the settled-once result of an asynchronous task. Unlike a raw
+// result channel it can be read any number of times, from any goroutine.
+type Future[T any] struct {
+	done chan struct{}
+	res  Result[T] // written once, before done is closed
+}
+
+// NewFuture wraps a result channel such as the one returned by Go or GoCtx.
+// The channel must not be read elsewhere; if it already was, the Future
+// settles with ErrAlreadyAwaited.
+func NewFuture[T any](ch <-chan Result[T]) *Future[T] {
+	f := &Future[T]{done: make(chan struct{})}
+	go func() {
+		defer close(f.done)
+		res, ok := <-ch
+		f.res = recv(res, ok)
+	}()
+	return f
+}
+
+// GoFuture runs fn on a new goroutine and returns its Future.
+func GoFuture[T any](fn func() (T, error)) *Future[T] {
+	return NewFuture(Go(fn))
+}
+
+// Get blocks until the task settles and returns its value and error.
+func (f *Future[T]) Get() (T, error) {
+	<-f.done
+	return f.res.Data, f.res.Err
+}
+
+// GetCtx is Get that gives up with ctx.Err() once ctx is done.
+func (f *Future[T]) GetCtx(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.res.Data, f.res.Err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Done is closed once the task has settled.
+func (f *Future[T]) Done() <-chan struct{} { return f.done }
+
+// Chan returns a new channel that receives the result once the task
+// settles, so a Future can be passed to Await, AwaitAll and friends. Every
+// call returns its own channel; none of them compete for the result.
+func (f *Future[T]) Chan() <-chan Result[T] {
+	ch := make(chan Result[T], 1)
+	go func() {
+		defer close(ch)
+		<-f.done
+		ch <- f.res
+	}()
+	return ch
+}
+
+// IsDone reports whether the task has settled, without blocking.
+func (f *Future[T]) IsDone() bool {
+	select {
+	case <-f.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// OnComplete calls fn with the result once the task settles. Each callback
+// runs on its own goroutine, so callbacks are not ordered.
+func (f *Future[T]) OnComplete(fn func(T, error)) {
+	go func() {
+		<-f.done
+		fn(f.res.Data, f.res.Err)
+	}()
+}
+
+// Catch returns a Future that replaces a failure with the result of fn.
+// Successful values pass through untouched.
+func (f *Future[T]) Catch(fn func(error) (T, error)) *Future[T] {
+	return GoFuture(func() (T, error) {
+		v, err := f.Get()
+		if err != nil {
+			return fn(err)
+		}
+		return v, nil
+	})
+}
+
+// Finally returns a Future with the same result that settles only after fn
+// has run, whether the task succeeded or not.
+func (f *Future[T]) Finally(fn func()) *Future[T] {
+	return GoFuture(func() (T, error) {
+		v, err := f.Get()
+		fn()
+		return v, err
+	})
+}
+
+// Then chains fn onto a successful result. If f fails, fn is skipped and
+// the error is passed on.
+func Then[T, U any](f *Future[T], fn func(T) (U, error)) *Future[U] {
+	return GoFuture(func() (U, error) {
+		v, err := f.Get()
+		if err != nil {
+			var zero U
+			return zero, err
+		}
+		return fn(v)
+	})
+}
+
+// Map is Then for functions that cannot fail.
+func Map[T, U any](f *Future[T], fn func(T) U) *Future[U] {
+	return Then(f, func(v T) (U, error) { return fn(v), nil })
+}
@@ -0,0 +1,159 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestFutureGetIsRepeatable(t *testing.T) {
+	f := NewFuture(Go(func() (int, error) { return 7, nil }))
+
+	for range 3 {
+		got, err := f.Get()
+		if err != nil || got != 7 {
+			t.Fatalf("want 7, nil; got %d, %v", got, err)
+		}
+	}
+	if !f.IsDone() {
+		t.Fatal("future should be done after Get")
+	}
+}
+
+func TestFutureGetCtx(t *testing.T) {
+	block := make(chan Result[int])
+	f := NewFuture(block)
+	if f.IsDone() {
+		t.Fatal("future should not be done yet")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := f.GetCtx(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("want context.Canceled, got %v", err)
+	}
+
+	block <- Result[int]{Data: 3}
+	if got, err := f.GetCtx(context.Background()); err != nil || got != 3 {
+		t.Fatalf("want 3, nil; got %d, %v", got, err)
+	}
+}
+
+func TestThenAndMap(t *testing.T) {
+	f := GoFuture(func() (string, error) { return "21", nil })
+
+	doubled := Map(Then(f, strconv.Atoi), func(n int) int { return n * 2 })
+	if got, err := doubled.Get(); err != nil || got != 42 {
+		t.Fatalf("want 42, nil; got %d, %v", got, err)
+	}
+
+	bad := Then(GoFuture(func() (string, error) { return "x", nil }), strconv.Atoi)
+	if _, err := bad.Get(); !errors.Is(err, strconv.ErrSyntax) {
+		t.Fatalf("want strconv.ErrSyntax, got %v", err)
+	}
+}
+
+func TestThenSkipsOnFailure(t *testing.T) {
+	e := errors.New("boom")
+	called := false
+
+	_, err := Then(NewFuture(ready(0, e)), func(int) (int, error) { called = true; return 1, nil }).Get()
+	if !errors.Is(err, e) {
+		t.Fatalf("want %v, got %v", e, err)
+	}
+	if called {
+		t.Fatal("Then should not run after a failure")
+	}
+}
+
+func TestCatchAndFinally(t *testing.T) {
+	e := errors.New("boom")
+	finished := false
+
+	f := NewFuture(ready(0, e)).
+		Catch(func(err error) (int, error) { return -1, nil }).
+		Finally(func() { finished = true })
+
+	got, err := f.Get()
+	if err != nil || got != -1 {
+		t.Fatalf("want -1, nil; got %d, %v", got, err)
+	}
+	if !finished {
+		t.Fatal("Finally should run before the future settles")
+	}
+
+	ok := NewFuture(ready(5, nil)).Catch(func(error) (int, error) { return -1, nil })
+	if got, _ := ok.Get(); got != 5 {
+		t.Fatalf("Catch should pass successes through, got %d", got)
+	}
+}
+
+func TestOnComplete(t *testing.T) {
+	e := errors.New("boom")
+	got := make(chan error, 2)
+
+	f := NewFuture(ready(0, e))
+	f.OnComplete(func(_ int, err error) { got <- err })
+	f.Get()
+	f.OnComplete(func(_ int, err error) { got <- err }) // registered after settling
+
+	for range 2 {
+		if err := <-got; !errors.Is(err, e) {
+			t.Fatalf("want %v, got %v", e, err)
+		}
+	}
+}
+
+func TestFutureManyAwaiters(t *testing.T) {
+	e := errors.New("boom")
+	release := make(chan struct{})
+	f := GoFuture(func() (int, error) { <-release; return 9, e })
+
+	const n = 50
+	var wg sync.WaitGroup
+	results := make([]Result[int], n)
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if i%2 == 0 {
+				results[i].Data, results[i].Err = f.Get()
+			} else {
+				results[i].Data, results[i].Err = Await(f.Chan())
+			}
+		}()
+	}
+	close(release)
+	wg.Wait()
+
+	for i, r := range results {
+		if r.Data != 9 || !errors.Is(r.Err, e) {
+			t.Fatalf("awaiter %d: want 9, %v; got %d, %v", i, e, r.Data, r.Err)
+		}
+	}
+}
+
+func TestFutureChanWithAwaitAll(t *testing.T) {
+	f := GoFuture(func() (string, error) { return "shared", nil })
+
+	got, err := AwaitAll(f.Chan(), f.Chan())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got[0] != "shared" || got[1] != "shared" {
+		t.Fatalf("want both awaiters to see the value, got %v", got)
+	}
+}
+
+func TestNewFutureOnAwaitedChannel(t *testing.T) {
+	ch := Go(func() (int, error) { return 7, nil })
+	if _, err := Await(ch); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := NewFuture(ch).Get(); !errors.Is(err, ErrAlreadyAwaited) {
+		t.Fatalf("want ErrAlreadyAwaited, got %v", err)
+	}
+}
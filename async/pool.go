@@ -0,0 +1,133 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrPoolClosed is the result of tasks submitted after Shutdown.
+var ErrPoolClosed = errors.New("async: pool is shut down")
+
+// Pool runs submitted tasks on a fixed number of workers, queueing up to a
+// bounded number of pending tasks. One Pool can serve tasks of any result
+// type, so it caps total concurrency against a shared resource.
+type Pool struct {
+	tasks   chan func()
+	workers int
+	done    chan struct{} // closed once every worker has exited
+
+	mu         sync.Mutex
+	closed     bool
+	closing    chan struct{}  // closed by Shutdown to release blocked submitters
+	submitting sync.WaitGroup // submitters that may still send on tasks
+
+	active    atomic.Int64
+	completed atomic.Uint64
+}
+
+// PoolStats is a snapshot of a Pool's load.
+type PoolStats struct {
+	Workers    int    // fixed number of workers
+	Active     int    // tasks currently running
+	QueueDepth int    // tasks waiting for a worker
+	Completed  uint64 // tasks finished since the pool started
+}
+
+// NewPool starts workers goroutines (at least one) with room for queueSize
+// pending tasks.
+func NewPool(workers, queueSize int) *Pool {
+	p := &Pool{
+		tasks:   make(chan func(), max(0, queueSize)),
+		workers: max(1, workers),
+		done:    make(chan struct{}),
+		closing: make(chan struct{}),
+	}
+
+	var wg sync.WaitGroup
+	for range p.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for task := range p.tasks {
+				p.active.Add(1)
+				task()
+				p.active.Add(-1)
+				p.completed.Add(1)
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(p.done)
+	}()
+	return p
+}
+
+// Submit queues fn on p, blocking while the queue is full. If the pool is
+// shut down first, the returned channel carries ErrPoolClosed and fn never
+// runs.
+func Submit[T any](p *Pool, fn func() (T, error)) <-chan Result[T] {
+	ch := make(chan Result[T], 1)
+	task := func() {
+		defer close(ch)
+		v, err := fn()
+		ch <- Result[T]{Data: v, Err: err}
+	}
+	rejected := func() <-chan Result[T] {
+		ch <- Result[T]{Err: ErrPoolClosed}
+		close(ch)
+		return ch
+	}
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return rejected()
+	}
+	p.submitting.Add(1)
+	p.mu.Unlock()
+	defer p.submitting.Done()
+
+	select {
+	case p.tasks <- task:
+		return ch
+	case <-p.closing:
+		return rejected()
+	}
+}
+
+// Shutdown stops accepting tasks and waits for queued and running ones to
+// finish. Submitters still waiting for queue space get ErrPoolClosed. If ctx
+// ends first it returns ctx.Err(); the workers keep draining in the
+// background.
+func (p *Pool) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	first := !p.closed
+	p.closed = true
+	p.mu.Unlock()
+
+	if first {
+		close(p.closing)
+		p.submitting.Wait() // no one sends on tasks after this
+		close(p.tasks)
+	}
+
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Stats reports the pool's current load.
+func (p *Pool) Stats() PoolStats {
+	return PoolStats{
+		Workers:    p.workers,
+		Active:     int(p.active.Load()),
+		QueueDepth: len(p.tasks),
+		Completed:  p.completed.Load(),
+	}
+}
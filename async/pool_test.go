@@ -0,0 +1,155 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPoolRunsTasks(t *testing.T) {
+	p := NewPool(2, 4)
+	defer p.Shutdown(context.Background())
+
+	chs := make([]<-chan Result[int], 5)
+	for i := range chs {
+		chs[i] = Submit(p, func() (int, error) { return i * i, nil })
+	}
+
+	got, err := AwaitAll(chs...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, v := range got {
+		if v != i*i {
+			t.Fatalf("want %d at %d, got %d", i*i, i, v)
+		}
+	}
+}
+
+func TestPoolBoundsConcurrency(t *testing.T) {
+	const workers = 3
+	p := NewPool(workers, 10)
+
+	var running, peak atomic.Int32
+	release := make(chan struct{})
+	chs := make([]<-chan Result[struct{}], 10)
+	for i := range chs {
+		chs[i] = Submit(p, func() (struct{}, error) {
+			n := running.Add(1)
+			for {
+				old := peak.Load()
+				if n <= old || peak.CompareAndSwap(old, n) {
+					break
+				}
+			}
+			<-release
+			running.Add(-1)
+			return struct{}{}, nil
+		})
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for p.Stats().Active < workers && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	st := p.Stats()
+	if st.Workers != workers || st.Active != workers || st.QueueDepth != 10-workers {
+		t.Fatalf("unexpected stats while saturated: %+v", st)
+	}
+
+	close(release)
+	if _, err := AwaitAll(chs...); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if peak.Load() > workers {
+		t.Fatalf("want at most %d concurrent tasks, saw %d", workers, peak.Load())
+	}
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected shutdown error: %v", err)
+	}
+	if st := p.Stats(); st.Completed != 10 || st.Active != 0 {
+		t.Fatalf("unexpected stats after shutdown: %+v", st)
+	}
+}
+
+func TestPoolShutdownDrainsQueue(t *testing.T) {
+	p := NewPool(1, 5)
+
+	var ran atomic.Int32
+	chs := make([]<-chan Result[int], 5)
+	for i := range chs {
+		chs[i] = Submit(p, func() (int, error) {
+			time.Sleep(time.Millisecond)
+			ran.Add(1)
+			return i, nil
+		})
+	}
+
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected shutdown error: %v", err)
+	}
+	if ran.Load() != 5 {
+		t.Fatalf("want every queued task to finish, ran %d", ran.Load())
+	}
+	if _, err := AwaitAll(chs...); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	late := Submit(p, func() (int, error) { return 1, nil })
+	if _, err := Await(late); !errors.Is(err, ErrPoolClosed) {
+		t.Fatalf("want ErrPoolClosed, got %v", err)
+	}
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Fatalf("second shutdown should be a no-op, got %v", err)
+	}
+}
+
+func TestPoolShutdownHonoursContext(t *testing.T) {
+	p := NewPool(1, 0)
+	release := make(chan struct{})
+	ch := Submit(p, func() (int, error) { <-release; return 1, nil })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := p.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("want context.DeadlineExceeded, got %v", err)
+	}
+
+	close(release) // the in-flight task still completes
+	if got, err := Await(ch); err != nil || got != 1 {
+		t.Fatalf("want 1, nil; got %d, %v", got, err)
+	}
+}
+
+func TestPoolShutdownReleasesBlockedSubmitters(t *testing.T) {
+	p := NewPool(1, 0)
+	release := make(chan struct{})
+	running := Submit(p, func() (int, error) { <-release; return 1, nil })
+	for p.Stats().Active != 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	blocked := make(chan (<-chan Result[int]), 1)
+	go func() { blocked <- Submit(p, func() (int, error) { return 2, nil }) }()
+	time.Sleep(5 * time.Millisecond) // let the submitter block on the full queue
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := p.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("want context.DeadlineExceeded, got %v", err)
+	}
+	if waited := time.Since(start); waited > 500*time.Millisecond {
+		t.Fatalf("Shutdown should honour its deadline, waited %v", waited)
+	}
+
+	if _, err := Await(<-blocked); !errors.Is(err, ErrPoolClosed) {
+		t.Fatalf("blocked submitter should get ErrPoolClosed, got %v", err)
+	}
+	close(release)
+	if got, err := Await(running); err != nil || got != 1 {
+		t.Fatalf("want 1, nil; got %d, %v", got, err)
+	}
+}
@@ -0,0 +1,114 @@
+package async
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// Jitter decides how retry delays are randomised.
+type Jitter int
+
+const (
+	NoJitter           Jitter = iota // exact exponential delays
+	FullJitter                       // uniform in [0, exponential delay)
+	DecorrelatedJitter               // uniform in [InitialInterval, 3 × previous delay)
+)
+
+// RetryPolicy configures Retry. Zero fields take the documented defaults.
+type RetryPolicy struct {
+	MaxAttempts     int           // total tries including the first; 0 = unlimited
+	InitialInterval time.Duration // first delay; default 100ms
+	MaxInterval     time.Duration // cap on any single delay; 0 = no cap
+	Multiplier      float64       // growth per attempt; default 2
+	Jitter          Jitter
+	MaxElapsedTime  time.Duration // give up once the next try would start later; 0 = no limit
+
+	// Retryable reports whether err is worth another try; nil retries all.
+	Retryable func(error) bool
+	// OnRetry is called before waiting delay for attempt+1.
+	OnRetry func(attempt int, err error, delay time.Duration)
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.InitialInterval <= 0 {
+		p.InitialInterval = 100 * time.Millisecond
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = 2
+	}
+	return p
+}
+
+// delay returns the wait after the given attempt; prev is the previous
+// delay, used by DecorrelatedJitter.
+func (p RetryPolicy) delay(attempt int, prev time.Duration) time.Duration {
+	limit := time.Duration(math.MaxInt64)
+	if p.MaxInterval > 0 {
+		limit = p.MaxInterval
+	}
+
+	if p.Jitter == DecorrelatedJitter {
+		hi := max(3*prev, p.InitialInterval+1)
+		return min(limit, p.InitialInterval+rand.N(hi-p.InitialInterval))
+	}
+
+	exp := float64(p.InitialInterval)
+	for range attempt - 1 {
+		if exp *= p.Multiplier; exp >= float64(limit) {
+			break
+		}
+	}
+	d := limit
+	if exp < float64(limit) {
+		d = time.Duration(exp)
+	}
+	if p.Jitter == FullJitter {
+		d = rand.N(max(d, 1))
+	}
+	return d
+}
+
+// Retry runs fn until it succeeds, returns a non-retryable error, or the
+// policy's attempt or time budget runs out, waiting with exponential
+// backoff between tries. The result carries the last error from fn, or
+// ctx.Err() if ctx ends first.
+func Retry[T any](ctx context.Context, policy RetryPolicy, fn func(context.Context) (T, error)) <-chan Result[T] {
+	p := policy.withDefaults()
+
+	return GoCtx(ctx, func(ctx context.Context) (T, error) {
+		start := time.Now()
+		var prev time.Duration
+		for attempt := 1; ; attempt++ {
+			v, err := fn(ctx)
+			if err == nil {
+				return v, nil
+			}
+			if p.MaxAttempts > 0 && attempt >= p.MaxAttempts {
+				return v, err
+			}
+			if p.Retryable != nil && !p.Retryable(err) {
+				return v, err
+			}
+
+			d := p.delay(attempt, prev)
+			prev = d
+			if p.MaxElapsedTime > 0 && time.Since(start)+d > p.MaxElapsedTime {
+				return v, err
+			}
+			if p.OnRetry != nil {
+				p.OnRetry(attempt, err, d)
+			}
+
+			t := time.NewTimer(d)
+			select {
+			case <-t.C:
+			case <-ctx.Done():
+				t.Stop()
+				var zero T
+				return zero, ctx.Err()
+			}
+		}
+	})
+}
@@ -0,0 +1,119 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// flaky fails the first n calls with err, then returns the call count.
+func flaky(n int, err error) (func(context.Context) (int, error), *int) {
+	calls := 0
+	return func(context.Context) (int, error) {
+		calls++
+		if calls <= n {
+			return 0, err
+		}
+		return calls, nil
+	}, &calls
+}
+
+func TestRetrySucceedsAfterFailures(t *testing.T) {
+	fn, calls := flaky(3, errors.New("transient"))
+	var delays []time.Duration
+
+	got, err := Await(Retry(context.Background(), RetryPolicy{
+		InitialInterval: time.Millisecond,
+		OnRetry:         func(_ int, _ error, d time.Duration) { delays = append(delays, d) },
+	}, fn))
+	if err != nil || got != 4 {
+		t.Fatalf("want 4, nil; got %d, %v", got, err)
+	}
+	if *calls != 4 {
+		t.Fatalf("want 4 calls, got %d", *calls)
+	}
+	want := []time.Duration{time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond}
+	if !reflect.DeepEqual(delays, want) {
+		t.Fatalf("want exponential delays %v, got %v", want, delays)
+	}
+}
+
+func TestRetryMaxAttempts(t *testing.T) {
+	e := errors.New("down")
+	fn, calls := flaky(10, e)
+
+	_, err := Await(Retry(context.Background(), RetryPolicy{MaxAttempts: 3, InitialInterval: time.Microsecond}, fn))
+	if !errors.Is(err, e) {
+		t.Fatalf("want %v, got %v", e, err)
+	}
+	if *calls != 3 {
+		t.Fatalf("want 3 calls, got %d", *calls)
+	}
+}
+
+func TestRetryStopsOnPermanentError(t *testing.T) {
+	permanent := errors.New("not found")
+	fn, calls := flaky(10, permanent)
+
+	_, err := Await(Retry(context.Background(), RetryPolicy{
+		InitialInterval: time.Microsecond,
+		Retryable:       func(err error) bool { return !errors.Is(err, permanent) },
+	}, fn))
+	if !errors.Is(err, permanent) || *calls != 1 {
+		t.Fatalf("want one call failing with %v; got %d calls, %v", permanent, *calls, err)
+	}
+}
+
+func TestRetryMaxElapsedTime(t *testing.T) {
+	e := errors.New("down")
+	fn, calls := flaky(100, e)
+
+	_, err := Await(Retry(context.Background(), RetryPolicy{
+		InitialInterval: 10 * time.Millisecond,
+		MaxElapsedTime:  25 * time.Millisecond, // 10ms + 20ms would overrun
+	}, fn))
+	if !errors.Is(err, e) {
+		t.Fatalf("want %v, got %v", e, err)
+	}
+	if *calls != 2 {
+		t.Fatalf("want 2 calls within budget, got %d", *calls)
+	}
+}
+
+func TestRetryHonoursContext(t *testing.T) {
+	fn, _ := flaky(100, errors.New("down"))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := Await(Retry(ctx, RetryPolicy{InitialInterval: time.Hour}, fn))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("want context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestRetryDelayBounds(t *testing.T) {
+	p := RetryPolicy{InitialInterval: 10 * time.Millisecond, MaxInterval: 50 * time.Millisecond}.withDefaults()
+
+	if d := p.delay(10, 0); d != 50*time.Millisecond {
+		t.Fatalf("want delay capped at MaxInterval, got %v", d)
+	}
+
+	p.Jitter = FullJitter
+	for attempt := 1; attempt <= 5; attempt++ {
+		if d := p.delay(attempt, 0); d < 0 || d >= 50*time.Millisecond {
+			t.Fatalf("full jitter delay %v out of range", d)
+		}
+	}
+
+	p.Jitter = DecorrelatedJitter
+	prev := time.Duration(0)
+	for range 20 {
+		d := p.delay(0, prev)
+		if d < p.InitialInterval || d > p.MaxInterval {
+			t.Fatalf("decorrelated delay %v out of range", d)
+		}
+		prev = d
+	}
+}
@@ -0,0 +1,149 @@
+package async
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrWeightTooLarge is returned when a single acquisition asks for more
+// than the semaphore's total capacity, which could never succeed.
+var ErrWeightTooLarge = errors.New("async: weight exceeds semaphore capacity")
+
+// ErrInvalidWeight is returned when asking for a weight that is not
+// positive.
+var ErrInvalidWeight = errors.New("async: semaphore weight must be positive")
+
+// Semaphore limits concurrent work by weight, e.g. megabytes of memory.
+// Waiters are served in FIFO order: a large request at the head of the queue
+// holds back smaller ones behind it, so it is never starved.
+type Semaphore struct {
+	size    int64
+	mu      sync.Mutex
+	cur     int64
+	waiters list.List // of *semWaiter, oldest first
+}
+
+type semWaiter struct {
+	n     int64
+	ready chan struct{} // closed once the weight has been granted
+}
+
+// NewSemaphore returns a semaphore with total capacity n.
+func NewSemaphore(n int64) *Semaphore {
+	return &Semaphore{size: n}
+}
+
+// Acquire blocks until weight n is available or ctx is done, in which case
+// it returns ctx.Err(). A weight that could never be granted fails at once
+// with ErrInvalidWeight or ErrWeightTooLarge. On failure nothing is held.
+func (s *Semaphore) Acquire(ctx context.Context, n int64) error {
+	if n <= 0 {
+		return ErrInvalidWeight
+	}
+	s.mu.Lock()
+	if n > s.size {
+		s.mu.Unlock()
+		return ErrWeightTooLarge
+	}
+	if s.size-s.cur >= n && s.waiters.Len() == 0 {
+		s.cur += n
+		s.mu.Unlock()
+		return nil
+	}
+
+	w := &semWaiter{n: n, ready: make(chan struct{})}
+	el := s.waiters.PushBack(w)
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	select {
+	case <-w.ready: // granted while giving up: hand the weight back
+		s.cur -= n
+	default:
+		s.waiters.Remove(el)
+	}
+	s.notify() // leaving the head may unblock smaller waiters
+	return ctx.Err()
+}
+
+// TryAcquire takes weight n only if it is available right now and nobody
+// is already waiting. A non-positive n is never granted.
+func (s *Semaphore) TryAcquire(n int64) bool {
+	if n <= 0 {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.size-s.cur >= n && s.waiters.Len() == 0 {
+		s.cur += n
+		return true
+	}
+	return false
+}
+
+// Release returns weight n. Releasing more than is held, or a non-positive
+// n, panics.
+func (s *Semaphore) Release(n int64) {
+	if n <= 0 {
+		panic("async: semaphore released a non-positive weight")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cur -= n
+	if s.cur < 0 {
+		panic("async: semaphore released more than held")
+	}
+	s.notify()
+}
+
+// notify grants weight to waiters in order, stopping at the first one that
+// does not fit. Callers hold s.mu.
+func (s *Semaphore) notify() {
+	for {
+		front := s.waiters.Front()
+		if front == nil {
+			return
+		}
+		w := front.Value.(*semWaiter)
+		if s.size-s.cur < w.n {
+			return
+		}
+		s.cur += w.n
+		s.waiters.Remove(front)
+		close(w.ready)
+	}
+}
+
+// Limit wraps fn so each call holds weight on s while it runs, e.g.
+// async.Go(async.Limit(sem, 64, loadBlob)).
+func Limit[T any](s *Semaphore, weight int64, fn func() (T, error)) func() (T, error) {
+	return func() (T, error) {
+		if err := s.Acquire(context.Background(), weight); err != nil {
+			var zero T
+			return zero, err
+		}
+		defer s.Release(weight)
+		return fn()
+	}
+}
+
+// LimitCtx is Limit for GoCtx tasks: waiting for weight stops when ctx does.
+func LimitCtx[T any](s *Semaphore, weight int64, fn func(context.Context) (T, error)) func(context.Context) (T, error) {
+	return func(ctx context.Context) (T, error) {
+		if err := s.Acquire(ctx, weight); err != nil {
+			var zero T
+			return zero, err
+		}
+		defer s.Release(weight)
+		return fn(ctx)
+	}
+}
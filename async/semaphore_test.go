@@ -0,0 +1,164 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSemaphoreTryAcquire(t *testing.T) {
+	s := NewSemaphore(10)
+
+	if !s.TryAcquire(6) {
+		t.Fatal("want 6 of 10 to be available")
+	}
+	if s.TryAcquire(5) {
+		t.Fatal("only 4 remain")
+	}
+	if !s.TryAcquire(4) {
+		t.Fatal("want the remaining 4 to be available")
+	}
+	s.Release(10)
+	if !s.TryAcquire(10) {
+		t.Fatal("want full capacity after release")
+	}
+}
+
+func TestSemaphoreAcquireBlocksUntilRelease(t *testing.T) {
+	s := NewSemaphore(4)
+	s.TryAcquire(3)
+
+	acquired := make(chan error, 1)
+	go func() { acquired <- s.Acquire(context.Background(), 2) }()
+
+	select {
+	case <-acquired:
+		t.Fatal("Acquire should wait while only 1 is free")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	s.Release(3)
+	if err := <-acquired; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSemaphoreIsFIFO(t *testing.T) {
+	s := NewSemaphore(10)
+	s.TryAcquire(8)
+
+	large := make(chan error, 1)
+	go func() { large <- s.Acquire(context.Background(), 10) }()
+	for !waiting(s, 1) {
+		time.Sleep(time.Millisecond)
+	}
+
+	// 2 units are free, but a small request must queue behind the large one
+	if s.TryAcquire(1) {
+		t.Fatal("TryAcquire should not jump the queue")
+	}
+	small := make(chan error, 1)
+	go func() { small <- s.Acquire(context.Background(), 1) }()
+	for !waiting(s, 2) {
+		time.Sleep(time.Millisecond)
+	}
+
+	s.Release(8)
+	if err := <-large; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	select {
+	case <-small:
+		t.Fatal("small request should wait for the large one to release")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	s.Release(10)
+	if err := <-small; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSemaphoreAcquireCancelled(t *testing.T) {
+	s := NewSemaphore(2)
+	s.TryAcquire(2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := s.Acquire(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("want context.DeadlineExceeded, got %v", err)
+	}
+	if waiting(s, 1) {
+		t.Fatal("a cancelled waiter should leave the queue")
+	}
+
+	if err := s.Acquire(context.Background(), 3); !errors.Is(err, ErrWeightTooLarge) {
+		t.Fatalf("want ErrWeightTooLarge, got %v", err)
+	}
+}
+
+func TestLimit(t *testing.T) {
+	s := NewSemaphore(3)
+	var running, peak atomic.Int32
+
+	task := Limit(s, 2, func() (int, error) { // only one task fits at a time
+		n := running.Add(1)
+		if n > peak.Load() {
+			peak.Store(n)
+		}
+		time.Sleep(time.Millisecond)
+		running.Add(-1)
+		return 1, nil
+	})
+
+	chs := make([]<-chan Result[int], 5)
+	for i := range chs {
+		chs[i] = Go(task)
+	}
+	if _, err := AwaitAll(chs...); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if peak.Load() != 1 {
+		t.Fatalf("want one task at a time, saw %d", peak.Load())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.TryAcquire(3)
+	limited := LimitCtx(s, 1, func(context.Context) (int, error) { return 1, nil })
+	if _, err := limited(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("want context.Canceled, got %v", err)
+	}
+}
+
+// waiting reports whether exactly n callers are queued on s.
+func waiting(s *Semaphore, n int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.waiters.Len() == n
+}
+
+func TestSemaphoreRejectsNonPositiveWeight(t *testing.T) {
+	s := NewSemaphore(4)
+
+	for _, n := range []int64{0, -64} {
+		if err := s.Acquire(context.Background(), n); !errors.Is(err, ErrInvalidWeight) {
+			t.Fatalf("Acquire(%d): want ErrInvalidWeight, got %v", n, err)
+		}
+		if s.TryAcquire(n) {
+			t.Fatalf("TryAcquire(%d) should fail", n)
+		}
+	}
+	if !s.TryAcquire(4) || s.TryAcquire(1) {
+		t.Fatal("capacity must be unchanged by rejected weights")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Release(-1) should panic")
+		}
+	}()
+	s.Release(-1)
+}
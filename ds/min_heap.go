@@ -47,6 +47,14 @@ func (h *MinHeap[T]) IsEmpty() bool {
 	return len(h.data) == 0
 }
 
+// Clone returns an independent copy of the heap.
+func (h *MinHeap[T]) Clone() *MinHeap[T] {
+	return &MinHeap[T]{
+		data: append([]T(nil), h.data...),
+		less: h.less,
+	}
+}
+
 func (h *MinHeap[T]) ToSortedSlice() []T {
 	out := make([]T, 0, len(h.data))
 	for !h.IsEmpty() {
@@ -48,3 +48,14 @@ func TestMinHeap(t *testing.T) {
 		assert.Equal(t, expectedRemaining[idx], v, "Remaining elements should be in correct order")
 	}
 }
+
+func TestMinHeapClone(t *testing.T) {
+	h := NewMinHeap(func(a, b int) bool { return a < b })
+	h.Push(3)
+	h.Push(1)
+	h.Push(2)
+
+	c := h.Clone()
+	assert.Equal(t, []int{1, 2, 3}, c.ToSortedSlice(), "clone keeps heap order")
+	assert.Equal(t, 3, h.Len(), "draining the clone leaves the original intact")
+}
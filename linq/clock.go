@@ -0,0 +1,48 @@
+package linq
+
+import "time"
+
+// Clock abstracts time for time-driven stages, so tests can advance time
+// instead of sleeping. SystemClock is the default; pass another with WithClock.
+type Clock interface {
+	Now() time.Time
+	NewAlarm(d time.Duration) Alarm
+	NewTicker(d time.Duration) Ticker
+}
+
+// Alarm fires once, d after it was started or last reset (see time.Timer).
+// As with time.Timer since Go 1.23, no stale tick is received after Stop or
+// Reset returns.
+type Alarm interface {
+	C() <-chan time.Time
+	Stop() bool // false if it had already fired or been stopped
+	Reset(d time.Duration) bool
+}
+
+// Ticker delivers ticks every period until stopped (see time.Ticker).
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// SystemClock is the real wall clock.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) NewAlarm(d time.Duration) Alarm { return systemAlarm{time.NewTimer(d)} }
+
+func (systemClock) NewTicker(d time.Duration) Ticker { return systemTicker{time.NewTicker(d)} }
+
+type systemTicker struct{ t *time.Ticker }
+
+func (t systemTicker) C() <-chan time.Time { return t.t.C }
+func (t systemTicker) Stop()               { t.t.Stop() }
+
+type systemAlarm struct{ t *time.Timer }
+
+func (a systemAlarm) C() <-chan time.Time        { return a.t.C }
+func (a systemAlarm) Stop() bool                 { return a.t.Stop() }
+func (a systemAlarm) Reset(d time.Duration) bool { return a.t.Reset(d) }
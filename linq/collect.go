@@ -0,0 +1,106 @@
+package linq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/SaiNageswarS/go-collection-boot/ds"
+)
+
+// ErrDuplicateKey is returned by ToMap with FailOnDuplicate.
+var ErrDuplicateKey = errors.New("linq: duplicate key")
+
+// DuplicateKeyPolicy decides what ToMap does when two values share a key.
+type DuplicateKeyPolicy int
+
+const (
+	KeepFirst       DuplicateKeyPolicy = iota // ignore later values
+	KeepLast                                  // overwrite with later values
+	FailOnDuplicate                           // stop with ErrDuplicateKey
+)
+
+// FromSet starts a stream over the set's elements (in no particular order).
+func FromSet[T comparable](parent context.Context, set *ds.Set[T], opts ...StageOption) Stream[T] {
+	return fromSlice("FromSet", parent, set.ToSlice(), opts)
+}
+
+// FromMinHeap streams the heap's elements in ascending order. The heap itself
+// is left untouched.
+func FromMinHeap[T any](parent context.Context, h *ds.MinHeap[T], opts ...StageOption) Stream[T] {
+	return fromSlice("FromMinHeap", parent, h.Clone().ToSortedSlice(), opts)
+}
+
+func toMapFn[T any, K comparable, V any](s Stream[T], keyFn func(T) K, valFn func(T) V, onDup DuplicateKeyPolicy) (map[K]V, error) {
+	defer s.cancel(ErrShortCircuit) // close upstream transformers
+
+	out := make(map[K]V, s.cap)
+	for {
+		v, ok, err := tryRecv(s.ctx, s.C)
+		if err != nil { // context cancelled
+			return out, err
+		}
+		if !ok { // channel closed
+			return out, nil
+		}
+
+		key := keyFn(v)
+		if _, exists := out[key]; exists {
+			switch onDup {
+			case KeepFirst:
+				continue
+			case FailOnDuplicate:
+				return out, fmt.Errorf("%w: %v", ErrDuplicateKey, key)
+			}
+		}
+		out[key] = valFn(v)
+	}
+}
+
+func toSetFn[T comparable](s Stream[T]) (*ds.Set[T], error) {
+	out := ds.NewSet[T]()
+	_, err := forEachFn(s, func(v T) { out.Add(v) })
+	return out, err
+}
+
+func toMinHeapFn[T any](s Stream[T], less func(a, b T) bool) (*ds.MinHeap[T], error) {
+	out := ds.NewMinHeap(less)
+	_, err := forEachFn(s, out.Push)
+	return out, err
+}
+
+func toStackFn[T any](s Stream[T]) (*ds.Stack[T], error) {
+	out := ds.NewStack[T](s.cap)
+	_, err := forEachFn(s, out.Push)
+	return out, err
+}
+
+// ToMap collects values into a map keyed by keyFn; onDup decides how
+// repeated keys are handled.
+func ToMap[T any, K comparable, V any](keyFn func(T) K, valFn func(T) V, onDup DuplicateKeyPolicy) func(Stream[T]) (map[K]V, error) {
+	return func(s Stream[T]) (map[K]V, error) {
+		return toMapFn(s, keyFn, valFn, onDup)
+	}
+}
+
+// ToSet collects the distinct values into a ds.Set.
+func ToSet[T comparable]() func(Stream[T]) (*ds.Set[T], error) {
+	return func(s Stream[T]) (*ds.Set[T], error) {
+		return toSetFn(s)
+	}
+}
+
+// ToMinHeap collects values into a ds.MinHeap ordered by less.
+func ToMinHeap[T any](less func(a, b T) bool) func(Stream[T]) (*ds.MinHeap[T], error) {
+	return func(s Stream[T]) (*ds.MinHeap[T], error) {
+		return toMinHeapFn(s, less)
+	}
+}
+
+// ToStack pushes values onto a ds.Stack in stream order, so the last value
+// ends up on top.
+func ToStack[T any]() func(Stream[T]) (*ds.Stack[T], error) {
+	return func(s Stream[T]) (*ds.Stack[T], error) {
+		return toStackFn(s)
+	}
+}
@@ -0,0 +1,75 @@
+package linq
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SaiNageswarS/go-collection-boot/ds"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ToMap(t *testing.T) {
+	ctx := t.Context()
+	words := []string{"apple", "avocado", "banana"}
+	first := func(s string) string { return s[:1] }
+
+	got, err := Pipe1(FromSlice(ctx, words), ToMap(first, strings.ToUpper, KeepFirst))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "APPLE", "b": "BANANA"}, got)
+
+	got, err = Pipe1(FromSlice(ctx, words), ToMap(first, strings.ToUpper, KeepLast))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "AVOCADO", "b": "BANANA"}, got)
+
+	_, err = Pipe1(FromSlice(ctx, words), ToMap(first, strings.ToUpper, FailOnDuplicate))
+	assert.ErrorIs(t, err, ErrDuplicateKey)
+	assert.EqualError(t, err, "linq: duplicate key: a")
+}
+
+func Test_ToSet_FromSet(t *testing.T) {
+	ctx := t.Context()
+
+	set, err := Pipe1(FromSlice(ctx, []int{3, 1, 3, 2}), ToSet[int]())
+	assert.NoError(t, err)
+	assert.Equal(t, 3, set.Len())
+	assert.True(t, set.ContainsAll(1, 2, 3))
+
+	src := FromSet(ctx, set)
+	assert.Equal(t, "FromSet", src.Plan().String())
+
+	sum := 0
+	_, err = Pipe1(src, ForEach(func(n int) { sum += n }))
+	assert.NoError(t, err)
+	assert.Equal(t, 6, sum)
+}
+
+func Test_ToMinHeap_FromMinHeap(t *testing.T) {
+	ctx := t.Context()
+	less := func(a, b int) bool { return a < b }
+
+	h, err := Pipe1(FromSlice(ctx, []int{5, 2, 9, 1}), ToMinHeap(less))
+	assert.NoError(t, err)
+	top, _ := h.Peek()
+	assert.Equal(t, 1, top)
+
+	got, err := Pipe1(FromMinHeap(ctx, h), ToSlice[int]())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 5, 9}, got)
+	assert.Equal(t, 4, h.Len(), "source must not drain the heap")
+}
+
+func Test_ToStack(t *testing.T) {
+	ctx := t.Context()
+
+	st, err := Pipe1(FromSlice(ctx, []string{"a", "b", "c"}), ToStack[string]())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"c", "b", "a"}, drainStack(st))
+}
+
+func drainStack[T any](st *ds.Stack[T]) (out []T) {
+	for !st.Empty() {
+		v, _ := st.Pop()
+		out = append(out, v)
+	}
+	return
+}
@@ -0,0 +1,151 @@
+package linq
+
+import (
+	"container/list"
+	"fmt"
+	"time"
+)
+
+// distinctWindowFn suppresses keys among the n most recently seen ones (LRU).
+func distinctWindowFn[T any, K comparable](in Stream[T], keySelector func(T) K, n int, opts ...StageOption) Stream[T] {
+	n = max(1, n)
+	em := newEmitter[T](fmt.Sprintf("DistinctWindow(%d)", n), in.ctx, in.cancel, newStageConfig(max(1, in.cap/2), opts))
+	var g stageGroup
+
+	g.Go(func() {
+		defer close(em.out)
+		recent := list.New() // front = most recently seen
+		index := make(map[K]*list.Element, n)
+
+		for {
+			v, ok, err := tryRecv(in.ctx, in.C)
+			if err != nil || !ok { // cancelled or upstream closed
+				return
+			}
+
+			key := keySelector(v)
+			if el, seen := index[key]; seen {
+				recent.MoveToFront(el)
+				continue
+			}
+
+			index[key] = recent.PushFront(key)
+			if recent.Len() > n { // forget the least recently seen key
+				delete(index, recent.Remove(recent.Back()).(K))
+			}
+
+			if !em.send(v) {
+				return // downstream cancelled
+			}
+		}
+	})
+
+	return newStream(in.ctx, em.out, in.cancel, in.cap, g.waitFn(in.wait)).
+		WithStage(em.name, TransformStage, in.stage)
+}
+
+// distinctWithinFn suppresses a key for ttl after it was first emitted.
+func distinctWithinFn[T any, K comparable](in Stream[T], keySelector func(T) K, ttl time.Duration, opts ...StageOption) Stream[T] {
+	cfg := newStageConfig(max(1, in.cap/2), opts)
+	em := newEmitter[T](fmt.Sprintf("DistinctWithin(%v)", ttl), in.ctx, in.cancel, cfg)
+	var g stageGroup
+
+	type entry struct {
+		key     K
+		expires time.Time
+	}
+
+	g.Go(func() {
+		defer close(em.out)
+		order := list.New() // entries by expiry, oldest first
+		seen := make(map[K]struct{})
+
+		for {
+			v, ok, err := tryRecv(in.ctx, in.C)
+			if err != nil || !ok { // cancelled or upstream closed
+				return
+			}
+
+			now := cfg.clock.Now()
+			for front := order.Front(); front != nil; front = order.Front() {
+				e := front.Value.(entry)
+				if now.Before(e.expires) {
+					break
+				}
+				delete(seen, e.key)
+				order.Remove(front)
+			}
+
+			key := keySelector(v)
+			if _, dup := seen[key]; dup {
+				continue
+			}
+			seen[key] = struct{}{}
+			order.PushBack(entry{key: key, expires: now.Add(ttl)})
+
+			if !em.send(v) {
+				return // downstream cancelled
+			}
+		}
+	})
+
+	return newStream(in.ctx, em.out, in.cancel, in.cap, g.waitFn(in.wait)).
+		WithStage(em.name, TransformStage, in.stage)
+}
+
+// distinctUntilChangedFn drops values whose key equals the previous value's key.
+func distinctUntilChangedFn[T any, K comparable](in Stream[T], keySelector func(T) K, opts ...StageOption) Stream[T] {
+	em := newEmitter[T]("DistinctUntilChanged", in.ctx, in.cancel, newStageConfig(max(1, in.cap/2), opts))
+	var g stageGroup
+
+	g.Go(func() {
+		defer close(em.out)
+		var last K
+		first := true
+
+		for {
+			v, ok, err := tryRecv(in.ctx, in.C)
+			if err != nil || !ok { // cancelled or upstream closed
+				return
+			}
+
+			key := keySelector(v)
+			if !first && key == last {
+				continue
+			}
+			first, last = false, key
+
+			if !em.send(v) {
+				return // downstream cancelled
+			}
+		}
+	})
+
+	return newStream(in.ctx, em.out, in.cancel, in.cap, g.waitFn(in.wait)).
+		WithStage(em.name, TransformStage, in.stage)
+}
+
+// DistinctWindow deduplicates against the last n distinct keys only, so
+// memory stays bounded on infinite streams. A key evicted from the window
+// is emitted again the next time it shows up.
+func DistinctWindow[T any, K comparable](keySelector func(T) K, n int, opts ...StageOption) func(Stream[T]) Stream[T] {
+	return func(in Stream[T]) Stream[T] {
+		return distinctWindowFn(in, keySelector, n, opts...)
+	}
+}
+
+// DistinctWithin suppresses duplicates of a key for ttl after its first
+// emission; afterwards the key is forgotten. Time is read from the stage's
+// clock (see WithClock).
+func DistinctWithin[T any, K comparable](keySelector func(T) K, ttl time.Duration, opts ...StageOption) func(Stream[T]) Stream[T] {
+	return func(in Stream[T]) Stream[T] {
+		return distinctWithinFn(in, keySelector, ttl, opts...)
+	}
+}
+
+// DistinctUntilChanged suppresses only consecutive duplicates.
+func DistinctUntilChanged[T any, K comparable](keySelector func(T) K, opts ...StageOption) func(Stream[T]) Stream[T] {
+	return func(in Stream[T]) Stream[T] {
+		return distinctUntilChangedFn(in, keySelector, opts...)
+	}
+}
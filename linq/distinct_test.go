@@ -0,0 +1,36 @@
+package linq
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func identity[T any](v T) T { return v }
+
+func Test_DistinctWindow(t *testing.T) {
+	ctx := t.Context()
+
+	got, err := Pipe2(
+		FromSlice(ctx, []int{1, 2, 1, 3, 4, 1, 4}),
+		DistinctWindow(identity[int], 2),
+		ToSlice[int](),
+	)
+
+	// window holds 2 keys: 1 survives while re-seen, is evicted by 3 and 4
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 1}, got)
+}
+
+func Test_DistinctUntilChanged(t *testing.T) {
+	ctx := t.Context()
+
+	got, err := Pipe2(
+		FromSlice(ctx, []string{"a", "a", "b", "b", "a", "c", "c"}),
+		DistinctUntilChanged(identity[string]),
+		ToSlice[string](),
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "a", "c"}, got)
+}
@@ -0,0 +1,33 @@
+package linq
+
+import "errors"
+
+var (
+	// ErrEmptyStream is returned by sinks such as First that need at least
+	// one element.
+	ErrEmptyStream = errors.New("stream is empty, no first element found")
+
+	// ErrShortCircuit is the cancellation cause once a sink has returned,
+	// e.g. because First or Any already had its answer. Context-aware user
+	// functions see it via context.Cause.
+	ErrShortCircuit = errors.New("linq: pipeline finished by its sink")
+
+	// ErrClosed is the cancellation cause after Stream.Close.
+	ErrClosed = errors.New("linq: stream closed")
+)
+
+// StageError reports the stage whose failure ended a pipeline: a source
+// that could not read, a fetch or poll that failed, a buffer overflow, ….
+// Sinks return it, so callers can errors.As for the stage and errors.Is for
+// the underlying cause. Errors coming from the parent context (deadline,
+// external cancel) are returned unwrapped.
+type StageError struct {
+	Stage string // stage name as shown in Plan
+	Err   error
+}
+
+func (e *StageError) Error() string {
+	return "linq: stage " + e.Stage + ": " + e.Err.Error()
+}
+
+func (e *StageError) Unwrap() error { return e.Err }
@@ -0,0 +1,62 @@
+package linq
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_StageError_NamesFailingStage(t *testing.T) {
+	boom := errors.New("backend down")
+	fetch := func(context.Context, string) ([]int, string, error) { return nil, "", boom }
+
+	_, err := Pipe2(FromPages(t.Context(), fetch), Where(func(int) bool { return true }), ToSlice[int]())
+
+	var se *StageError
+	assert.ErrorAs(t, err, &se)
+	assert.Equal(t, "FromPages", se.Stage)
+	assert.ErrorIs(t, err, boom)
+	assert.EqualError(t, err, "linq: stage FromPages: backend down")
+}
+
+func Test_Errors_DistinguishCancellationReasons(t *testing.T) {
+	// external cancellation: the parent's error comes back unwrapped
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	_, err := Pipe1(FromSlice(ctx, []int{1}), ToSlice[int]())
+	assert.ErrorIs(t, err, context.Canceled)
+	var se *StageError
+	assert.False(t, errors.As(err, &se))
+
+	// internal short-circuit: workers observe ErrShortCircuit as the cause
+	causes := make(chan error, 1)
+	started := make(chan struct{})
+	s := SelectParCtx(func(ctx context.Context, n int) int {
+		if n == 1 {
+			<-started // emit 1 only once the second worker is running
+			return n
+		}
+		close(started)
+		<-ctx.Done()
+		causes <- context.Cause(ctx)
+		return n
+	})(FromSlice(t.Context(), []int{1, 2}))
+
+	first, err := Pipe1(s, First[int]())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, first)
+	s.Wait()
+	assert.ErrorIs(t, <-causes, ErrShortCircuit)
+
+	// stage failure: overflow is attributed to the stage
+	failed := overflowStage(t, []int{1, 2}, WithBuffer(1), WithOverflow(ErrorOnOverflow))
+	<-failed.ctx.Done() // the second value fails the pipeline
+
+	_, err = Pipe1(failed, ToSlice[int]())
+	assert.ErrorIs(t, err, ErrBufferOverflow)
+	if assert.ErrorAs(t, err, &se) {
+		assert.Equal(t, "Where", se.Stage)
+	}
+}
@@ -2,41 +2,138 @@ package linq
 
 import (
 	"context"
-	"errors"
 	"sync"
+	"sync/atomic"
 )
 
 // Stream is a lazy, cancel‑aware sequence of T.
 type Stream[T any] struct {
-	ctx    context.Context    // shared cancellation context
-	cancel context.CancelFunc // cancel function for short-circuit operations like All/Any/First
-	cap    int                // capacity hint for the channel. Advisory only, downstream can shrink it.
-	C      <-chan T           // receive‑only element channel
+	ctx    context.Context         // shared cancellation context
+	cancel context.CancelCauseFunc // cancels ctx with ErrShortCircuit, ErrClosed or a *StageError
+	cap    int                     // capacity hint for the channel. Advisory only, downstream can shrink it.
+	C      <-chan T                // receive‑only element channel
+	stage  *Stage                  // plan node describing how this stream was produced
+	wait   func()                  // blocks until this stage and everything upstream has exited
 }
 
 func NewStream[T any](ctx context.Context, out <-chan T, cancel context.CancelFunc, capHint int) Stream[T] {
-	return Stream[T]{ctx: ctx, C: out, cancel: cancel, cap: capHint}
+	return newStream(ctx, out, func(error) { cancel() }, capHint, nil)
+}
+
+func newStream[T any](ctx context.Context, out <-chan T, cancel context.CancelCauseFunc, capHint int, wait func()) Stream[T] {
+	return Stream[T]{ctx: ctx, C: out, cancel: cancel, cap: capHint, wait: wait}
+}
+
+// Close cancels the pipeline (with cause ErrClosed) and blocks until every
+// stage goroutine has exited. Use it when abandoning a stream without
+// handing it to a sink.
+func (s Stream[T]) Close() {
+	s.cancel(ErrClosed)
+	s.Wait()
+}
+
+// Wait blocks until every stage goroutine feeding s has exited, i.e. the
+// pipeline is fully drained or cancelled. Goroutines of custom stages built
+// with NewStream are not tracked.
+func (s Stream[T]) Wait() {
+	if s.wait != nil {
+		s.wait()
+	}
+}
+
+// liveStages counts running stage goroutines across all pipelines.
+var liveStages atomic.Int64
+
+// LiveStages reports how many stage goroutines are currently running in
+// the process. Tests use it to check that pipelines do not leak.
+func LiveStages() int {
+	return int(liveStages.Load())
+}
+
+// stageGroup tracks the goroutines started by one stage.
+type stageGroup struct {
+	wg sync.WaitGroup
+}
+
+func (g *stageGroup) Go(fn func()) {
+	liveStages.Add(1)
+	g.wg.Add(1)
+	go func() {
+		defer liveStages.Add(-1)
+		defer g.wg.Done()
+		fn()
+	}()
+}
+
+// waitFn returns the wait func of a stage whose inputs have the given waits.
+func (g *stageGroup) waitFn(upstream ...func()) func() {
+	return func() {
+		for _, w := range upstream {
+			if w != nil {
+				w()
+			}
+		}
+		g.wg.Wait()
+	}
 }
 
 // ---------- 1 · Sources ----------
 
 // FromSlice starts a new stream that emits the items one by one.
-func FromSlice[T any](parent context.Context, src []T) Stream[T] {
-	ctx, cancel := context.WithCancel(parent)
+func FromSlice[T any](parent context.Context, src []T, opts ...StageOption) Stream[T] {
+	return fromSlice("FromSlice", parent, src, opts)
+}
 
-	out := make(chan T, max(1, len(src)/2))
-	go func() {
-		defer close(out)
+func fromSlice[T any](name string, parent context.Context, src []T, opts []StageOption) Stream[T] {
+	ctx, cancel := context.WithCancelCause(parent)
+	em := newEmitter[T](name, ctx, cancel, newStageConfig(max(1, len(src)/2), opts))
+
+	var g stageGroup
+	g.Go(func() {
+		defer close(em.out)
 		for _, v := range src {
-			select {
-			case <-ctx.Done():
+			if !em.send(v) {
 				return
-			case out <- v:
 			}
 		}
-	}()
+	})
+
+	return newStream(ctx, em.out, cancel, len(src), g.waitFn()).WithStage(name, SourceStage)
+}
+
+// generate starts a source that emits whatever next produces until next
+// reports done or an error; an error fails the pipeline.
+func generate[T any](parent context.Context, name string, capHint int, opts []StageOption, next func(ctx context.Context) (v T, done bool, err error)) Stream[T] {
+	return generateInterruptible(parent, name, capHint, opts, nil, next)
+}
+
+// generateInterruptible is generate for sources whose next can block outside
+// the context's reach: if the pipeline is cancelled while the source is
+// still running, interrupt (when not nil) is called to unblock next.
+func generateInterruptible[T any](parent context.Context, name string, capHint int, opts []StageOption, interrupt func(), next func(ctx context.Context) (v T, done bool, err error)) Stream[T] {
+	ctx, cancel := context.WithCancelCause(parent)
+	em := newEmitter[T](name, ctx, cancel, newStageConfig(max(1, capHint/2), opts))
+
+	var g stageGroup
+	g.Go(func() {
+		defer close(em.out)
+		if interrupt != nil {
+			stop := context.AfterFunc(ctx, interrupt)
+			defer stop() // a finished source leaves its input alone
+		}
+		for ctx.Err() == nil {
+			v, done, err := next(ctx)
+			if err != nil {
+				em.fail(err)
+				return
+			}
+			if done || !em.send(v) {
+				return
+			}
+		}
+	})
 
-	return NewStream(ctx, out, cancel, len(src))
+	return newStream(ctx, em.out, cancel, capHint, g.waitFn()).WithStage(name, SourceStage)
 }
 
 func trySend[T any](ctx context.Context, out chan<- T, v T) bool {
@@ -51,8 +148,11 @@ func trySend[T any](ctx context.Context, out chan<- T, v T) bool {
 func tryRecv[T any](ctx context.Context, in <-chan T) (v T, ok bool, err error) {
 	select {
 	case <-ctx.Done():
-		return v, false, ctx.Err()
+		return v, false, context.Cause(ctx)
 	case v, ok = <-in:
+		if !ok && ctx.Err() != nil { // upstream closed because the pipeline was cancelled
+			return v, false, context.Cause(ctx)
+		}
 		return v, ok, nil
 	}
 }
@@ -60,11 +160,12 @@ func tryRecv[T any](ctx context.Context, in <-chan T) (v T, ok bool, err error)
 // ---------- 2 · Transformers ----------
 
 // Where keeps only the values for which pred == true.
-func whereFn[T any](in Stream[T], pred func(T) bool) Stream[T] {
-	out := make(chan T, max(1, in.cap/2))
+func whereFn[T any](name string, in Stream[T], pred func(context.Context, T) bool, opts ...StageOption) Stream[T] {
+	em := newEmitter[T](name, in.ctx, in.cancel, newStageConfig(max(1, in.cap/2), opts))
+	var g stageGroup
 
-	go func() {
-		defer close(out)
+	g.Go(func() {
+		defer close(em.out)
 		for {
 			v, ok, err := tryRecv(in.ctx, in.C)
 			if err != nil { // context cancelled
@@ -74,23 +175,24 @@ func whereFn[T any](in Stream[T], pred func(T) bool) Stream[T] {
 				return
 			}
 
-			if pred(v) {
-				if !trySend(in.ctx, out, v) {
+			if pred(in.ctx, v) {
+				if !em.send(v) {
 					return // downstream cancelled
 				}
 			}
 		}
-	}()
+	})
 
-	return NewStream(in.ctx, out, in.cancel, in.cap)
+	return newStream(in.ctx, em.out, in.cancel, in.cap, g.waitFn(in.wait)).WithStage(name, TransformStage, in.stage)
 }
 
 // Select maps T → U.
-func selectFn[T, U any](in Stream[T], f func(T) U) Stream[U] {
-	out := make(chan U, max(1, in.cap/2))
+func selectFn[T, U any](name string, in Stream[T], f func(context.Context, T) U, opts ...StageOption) Stream[U] {
+	em := newEmitter[U](name, in.ctx, in.cancel, newStageConfig(max(1, in.cap/2), opts))
+	var g stageGroup
 
-	go func() {
-		defer close(out)
+	g.Go(func() {
+		defer close(em.out)
 		for {
 			v, ok, err := tryRecv(in.ctx, in.C)
 			if err != nil { // context cancelled
@@ -100,25 +202,26 @@ func selectFn[T, U any](in Stream[T], f func(T) U) Stream[U] {
 				return
 			}
 
-			if !trySend(in.ctx, out, f(v)) {
+			if !em.send(f(in.ctx, v)) {
 				return
 			}
 		}
-	}()
+	})
 
-	return NewStream(in.ctx, out, in.cancel, in.cap)
+	return newStream(in.ctx, em.out, in.cancel, in.cap, g.waitFn(in.wait)).WithStage(name, TransformStage, in.stage)
 }
 
-func selectPar[T, U any](in Stream[T], f func(T) U) Stream[U] {
+func selectPar[T, U any](name string, in Stream[T], f func(context.Context, T) U, opts ...StageOption) Stream[U] {
 	// ── channels ───────────────────────────────────────────────
-	out := make(chan U, max(1, in.cap/2)) // final stream
+	em := newEmitter[U](name, in.ctx, in.cancel, newStageConfig(max(1, in.cap/2), opts)) // final stream
+	var g stageGroup
 	resCh := make(chan struct {
 		idx int
 		v   U
-	}, cap(out)) // workers → gatherer
+	}, max(1, cap(em.out))) // workers → gatherer
 
 	// ── 1. reader + worker launcher ────────────────────────────
-	go func() {
+	g.Go(func() {
 		var wg sync.WaitGroup
 		defer func() { // close resCh only after all workers return
 			wg.Wait()
@@ -137,19 +240,19 @@ func selectPar[T, U any](in Stream[T], f func(T) U) Stream[U] {
 			idx++
 
 			// one goroutine per element (unchanged)
-			go func(val T, i int) {
+			g.Go(func() {
 				defer wg.Done()
 				trySend(in.ctx, resCh, struct {
 					idx int
 					v   U
-				}{i, f(val)})
-			}(v, cur)
+				}{cur, f(in.ctx, v)})
+			})
 		}
-	}()
+	})
 
 	// ── 2. gatherer: restore order & stream downstream ─────────
-	go func() {
-		defer close(out)
+	g.Go(func() {
+		defer close(em.out)
 
 		next := 0
 		buffer := make(map[int]U)
@@ -165,7 +268,7 @@ func selectPar[T, U any](in Stream[T], f func(T) U) Stream[U] {
 						if !found {
 							return
 						}
-						if !trySend(in.ctx, out, v) {
+						if !em.send(v) {
 							return
 						}
 						delete(buffer, next)
@@ -174,7 +277,7 @@ func selectPar[T, U any](in Stream[T], f func(T) U) Stream[U] {
 				}
 
 				if r.idx == next {
-					if !trySend(in.ctx, out, r.v) {
+					if !em.send(r.v) {
 						return
 					}
 					next++
@@ -185,7 +288,7 @@ func selectPar[T, U any](in Stream[T], f func(T) U) Stream[U] {
 							break
 						}
 						delete(buffer, next)
-						if !trySend(in.ctx, out, v) {
+						if !em.send(v) {
 							return
 						}
 						next++
@@ -195,17 +298,18 @@ func selectPar[T, U any](in Stream[T], f func(T) U) Stream[U] {
 				}
 			}
 		}
-	}()
+	})
 
-	return NewStream(in.ctx, out, in.cancel, in.cap)
+	return newStream(in.ctx, em.out, in.cancel, in.cap, g.waitFn(in.wait)).WithStage(name, TransformStage, in.stage)
 }
 
-func distinctFn[T any, K comparable](in Stream[T], keySelector func(T) K) Stream[T] {
-	out := make(chan T, max(1, in.cap/2))
+func distinctFn[T any, K comparable](in Stream[T], keySelector func(T) K, opts ...StageOption) Stream[T] {
+	em := newEmitter[T]("Distinct", in.ctx, in.cancel, newStageConfig(max(1, in.cap/2), opts))
+	var g stageGroup
 	seen := make(map[K]struct{})
 
-	go func() {
-		defer close(out)
+	g.Go(func() {
+		defer close(em.out)
 		for {
 			v, ok, err := tryRecv(in.ctx, in.C)
 			if err != nil { // context cancelled
@@ -218,21 +322,23 @@ func distinctFn[T any, K comparable](in Stream[T], keySelector func(T) K) Stream
 			key := keySelector(v)
 			if _, exists := seen[key]; !exists {
 				seen[key] = struct{}{}
-				if !trySend(in.ctx, out, v) {
+				if !em.send(v) {
 					return // downstream cancelled
 				}
 			}
 		}
-	}()
+	})
 
-	return NewStream(in.ctx, out, in.cancel, in.cap)
+	return newStream(in.ctx, em.out, in.cancel, in.cap, g.waitFn(in.wait)).WithStage(em.name, TransformStage, in.stage)
 }
 
-func flattenFn[T any](in Stream[[]T]) Stream[T] {
-	out := make(chan T, 64) // heuristic buffer size since we don't know the size of the inner slices
+func flattenFn[T any](in Stream[[]T], opts ...StageOption) Stream[T] {
+	// heuristic buffer size since we don't know the size of the inner slices
+	em := newEmitter[T]("Flatten", in.ctx, in.cancel, newStageConfig(64, opts))
+	var g stageGroup
 
-	go func() {
-		defer close(out)
+	g.Go(func() {
+		defer close(em.out)
 		for {
 			v, ok, err := tryRecv(in.ctx, in.C)
 			if err != nil { // context cancelled
@@ -243,22 +349,23 @@ func flattenFn[T any](in Stream[[]T]) Stream[T] {
 			}
 
 			for _, item := range v {
-				if !trySend(in.ctx, out, item) {
+				if !em.send(item) {
 					return // downstream cancelled
 				}
 			}
 		}
-	}()
+	})
 
-	return NewStream(in.ctx, out, in.cancel, in.cap)
+	return newStream(in.ctx, em.out, in.cancel, in.cap, g.waitFn(in.wait)).WithStage(em.name, TransformStage, in.stage)
 }
 
-func groupByFn[T any, K comparable](in Stream[T], keySelector func(T) K) Stream[[]T] {
-	out := make(chan []T, max(1, in.cap/2))
+func groupByFn[T any, K comparable](in Stream[T], keySelector func(T) K, opts ...StageOption) Stream[[]T] {
+	em := newEmitter[[]T]("GroupBy", in.ctx, in.cancel, newStageConfig(max(1, in.cap/2), opts))
+	var g stageGroup
 	groups := make(map[K][]T)
 
-	go func() {
-		defer close(out)
+	g.Go(func() {
+		defer close(em.out)
 		for {
 			v, ok, err := tryRecv(in.ctx, in.C)
 			if err != nil { // context cancelled
@@ -267,7 +374,7 @@ func groupByFn[T any, K comparable](in Stream[T], keySelector func(T) K) Stream[
 			if !ok { // channel closed
 				// Emit all groups before exiting
 				for _, group := range groups {
-					if !trySend(in.ctx, out, group) {
+					if !em.send(group) {
 						return // downstream cancelled
 					}
 				}
@@ -277,16 +384,16 @@ func groupByFn[T any, K comparable](in Stream[T], keySelector func(T) K) Stream[
 			key := keySelector(v)
 			groups[key] = append(groups[key], v)
 		}
-	}()
+	})
 
-	return NewStream(in.ctx, out, in.cancel, in.cap)
+	return newStream(in.ctx, em.out, in.cancel, in.cap, g.waitFn(in.wait)).WithStage(em.name, TransformStage, in.stage)
 }
 
 // ---------- 3 · Sinks ----------
 
 // ToSlice collects every remaining element (honours ctx cancellation).
 func toSliceFn[T any](s Stream[T]) ([]T, error) {
-	defer s.cancel()
+	defer s.cancel(ErrShortCircuit)
 
 	out := make([]T, 0, s.cap)
 
@@ -305,7 +412,7 @@ func toSliceFn[T any](s Stream[T]) ([]T, error) {
 // ForEach applies fn to every element; stops early if ctx is cancelled.
 // returns placeholder struct to match the signature of a sink.
 func forEachFn[T any](s Stream[T], fn func(T)) (struct{}, error) {
-	defer s.cancel()
+	defer s.cancel(ErrShortCircuit)
 
 	for {
 		v, ok, err := tryRecv(s.ctx, s.C)
@@ -320,7 +427,7 @@ func forEachFn[T any](s Stream[T], fn func(T)) (struct{}, error) {
 }
 
 func countFn[T any](s Stream[T]) (int, error) {
-	defer s.cancel()
+	defer s.cancel(ErrShortCircuit)
 
 	count := 0
 	for {
@@ -336,7 +443,7 @@ func countFn[T any](s Stream[T]) (int, error) {
 }
 
 func firstFn[T any](s Stream[T]) (T, error) {
-	defer s.cancel() // close upstream transformers
+	defer s.cancel(ErrShortCircuit) // close upstream transformers
 
 	var zero T
 	v, ok, err := tryRecv(s.ctx, s.C)
@@ -344,14 +451,14 @@ func firstFn[T any](s Stream[T]) (T, error) {
 		return zero, err
 	}
 	if !ok { // channel closed
-		return zero, errors.New("stream is empty, no first element found")
+		return zero, ErrEmptyStream
 	}
 
 	return v, nil // return the first element found
 }
 
 func reverseFn[T any](s Stream[T]) ([]T, error) {
-	defer s.cancel() // close upstream transformers
+	defer s.cancel(ErrShortCircuit) // close upstream transformers
 
 	out := make([]T, 0, s.cap)
 
@@ -375,7 +482,7 @@ func reverseFn[T any](s Stream[T]) ([]T, error) {
 }
 
 func allFn[T any](s Stream[T], pred func(T) bool) (bool, error) {
-	defer s.cancel() // close upstream transformers
+	defer s.cancel(ErrShortCircuit) // close upstream transformers
 
 	for {
 		v, ok, err := tryRecv(s.ctx, s.C)
@@ -392,7 +499,7 @@ func allFn[T any](s Stream[T], pred func(T) bool) (bool, error) {
 }
 
 func anyFn[T any](s Stream[T], pred func(T) bool) (bool, error) {
-	defer s.cancel() // close upstream transformers
+	defer s.cancel(ErrShortCircuit) // close upstream transformers
 
 	for {
 		v, ok, err := tryRecv(s.ctx, s.C)
@@ -410,39 +517,64 @@ func anyFn[T any](s Stream[T], pred func(T) bool) (bool, error) {
 
 // ---------- 4 · Public "curried" Adapters ----------
 
-func Where[T any](pred func(T) bool) func(Stream[T]) Stream[T] {
+func Where[T any](pred func(T) bool, opts ...StageOption) func(Stream[T]) Stream[T] {
+	return func(in Stream[T]) Stream[T] {
+		return whereFn("Where", in, func(_ context.Context, v T) bool { return pred(v) }, opts...)
+	}
+}
+
+// WhereCtx is Where for predicates that need the pipeline context, e.g. to
+// abort a lookup once a sink has short-circuited.
+func WhereCtx[T any](pred func(context.Context, T) bool, opts ...StageOption) func(Stream[T]) Stream[T] {
 	return func(in Stream[T]) Stream[T] {
-		return whereFn(in, pred)
+		return whereFn("WhereCtx", in, pred, opts...)
+	}
+}
+
+func Select[T, U any](f func(T) U, opts ...StageOption) func(Stream[T]) Stream[U] {
+	return func(in Stream[T]) Stream[U] {
+		return selectFn("Select", in, func(_ context.Context, v T) U { return f(v) }, opts...)
+	}
+}
+
+// SelectCtx is Select for mappers that need the pipeline context. The
+// context is cancelled as soon as the pipeline ends, including when a sink
+// like First or Any short-circuits.
+func SelectCtx[T, U any](f func(context.Context, T) U, opts ...StageOption) func(Stream[T]) Stream[U] {
+	return func(in Stream[T]) Stream[U] {
+		return selectFn("SelectCtx", in, f, opts...)
 	}
 }
 
-func Select[T, U any](f func(T) U) func(Stream[T]) Stream[U] {
+func SelectPar[T, U any](f func(T) U, opts ...StageOption) func(Stream[T]) Stream[U] {
 	return func(in Stream[T]) Stream[U] {
-		return selectFn(in, f)
+		return selectPar("SelectPar", in, func(_ context.Context, v T) U { return f(v) }, opts...)
 	}
 }
 
-func SelectPar[T, U any](f func(T) U) func(Stream[T]) Stream[U] {
+// SelectParCtx is SelectPar for mappers that need the pipeline context, so
+// in-flight calls in the workers are aborted when the pipeline ends.
+func SelectParCtx[T, U any](f func(context.Context, T) U, opts ...StageOption) func(Stream[T]) Stream[U] {
 	return func(in Stream[T]) Stream[U] {
-		return selectPar(in, f)
+		return selectPar("SelectParCtx", in, f, opts...)
 	}
 }
 
-func Distinct[T any, K comparable](keySelector func(T) K) func(Stream[T]) Stream[T] {
+func Distinct[T any, K comparable](keySelector func(T) K, opts ...StageOption) func(Stream[T]) Stream[T] {
 	return func(in Stream[T]) Stream[T] {
-		return distinctFn(in, keySelector)
+		return distinctFn(in, keySelector, opts...)
 	}
 }
 
-func Flatten[T any]() func(Stream[[]T]) Stream[T] {
+func Flatten[T any](opts ...StageOption) func(Stream[[]T]) Stream[T] {
 	return func(in Stream[[]T]) Stream[T] {
-		return flattenFn(in)
+		return flattenFn(in, opts...)
 	}
 }
 
-func GroupBy[T any, K comparable](keySelector func(T) K) func(Stream[T]) Stream[[]T] {
+func GroupBy[T any, K comparable](keySelector func(T) K, opts ...StageOption) func(Stream[T]) Stream[[]T] {
 	return func(in Stream[T]) Stream[[]T] {
-		return groupByFn(in, keySelector)
+		return groupByFn(in, keySelector, opts...)
 	}
 }
 
@@ -2,6 +2,7 @@ package linq
 
 import (
 	"context"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -88,6 +89,7 @@ func TestStream_Any_All_Count_First(t *testing.T) {
 	)
 	assert.Error(t, err)
 	assert.EqualError(t, err, "stream is empty, no first element found")
+	assert.ErrorIs(t, err, ErrEmptyStream)
 
 	// ---- Total length (Count sink directly)
 	total, err := Pipe1(
@@ -325,3 +327,71 @@ func fromUnbufferedSlice[T any](parent context.Context, src []T) Stream[T] {
 
 	return NewStream(ctx, out, cancel, 0)
 }
+
+func Test_Close_StopsAbandonedPipeline(t *testing.T) {
+	ctx := t.Context()
+
+	s := SelectPar(func(n int) int { return n * 2 })(
+		Where(func(n int) bool { return n%2 == 0 })(
+			FromSlice(ctx, []int{1, 2, 3, 4, 5, 6, 7, 8}, WithBuffer(0)),
+		),
+	)
+
+	assert.Equal(t, 4, <-s.C) // take one item, then walk away
+	s.Close()                 // returns only once every stage has exited
+
+	_, err := Pipe1(s, Count[int]())
+	assert.ErrorIs(t, err, ErrClosed)
+}
+
+func Test_Wait_AfterSink(t *testing.T) {
+	ctx := t.Context()
+
+	s := Distinct(func(n int) int { return n })(FromSlice(ctx, []int{1, 1, 2}))
+	got, err := Pipe1(s, ToSlice[int]())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, got)
+
+	s.Wait() // drained pipeline: must not block
+
+	custom := fromUnbufferedSlice(ctx, []int{1})
+	custom.Close() // untracked goroutines: Close only cancels
+}
+
+func Test_CtxVariants(t *testing.T) {
+	ctx := t.Context()
+
+	got, err := Pipe3(
+		FromSlice(ctx, []int{1, 2, 3, 4}),
+		WhereCtx(func(ctx context.Context, n int) bool { return ctx.Err() == nil && n%2 == 0 }),
+		SelectCtx(func(ctx context.Context, n int) int { return n * 10 }),
+		ToSlice[int](),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{20, 40}, got)
+}
+
+func Test_SelectParCtx_AbortsInFlightOnShortCircuit(t *testing.T) {
+	ctx := t.Context()
+	aborted := make(chan struct{}, 8)
+	var started sync.WaitGroup
+	started.Add(2)
+
+	s := SelectParCtx(func(ctx context.Context, n int) int {
+		if n == 1 {
+			started.Wait() // answer once both slow calls are in flight
+			return n
+		}
+		started.Done()
+		<-ctx.Done() // simulates a slow call honouring cancellation
+		aborted <- struct{}{}
+		return n
+	})(FromSlice(ctx, []int{1, 2, 3}))
+
+	found, err := Pipe1(s, Any(func(n int) bool { return n == 1 }))
+	assert.NoError(t, err)
+	assert.True(t, found)
+
+	s.Wait() // would hang if the slow workers never observed cancellation
+	assert.Len(t, aborted, 2)
+}
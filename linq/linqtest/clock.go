@@ -0,0 +1,165 @@
+package linqtest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/SaiNageswarS/go-collection-boot/linq"
+)
+
+// FakeClock is a linq.Clock that only moves when Advance is called. Pass it
+// to time-driven stages with linq.WithClock.
+type FakeClock struct {
+	mu      sync.Mutex
+	changed *sync.Cond
+	now     time.Time
+	timers  []*fakeTimer
+}
+
+type fakeTimer struct {
+	ch     chan time.Time
+	when   time.Time
+	period time.Duration // 0 for one-shot timers
+}
+
+var _ linq.Clock = (*FakeClock)(nil)
+
+// NewFakeClock returns a clock frozen at start.
+func NewFakeClock(start time.Time) *FakeClock {
+	c := &FakeClock{now: start}
+	c.changed = sync.NewCond(&c.mu)
+	return c
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) NewAlarm(d time.Duration) linq.Alarm {
+	return fakeAlarm{clock: c, t: c.add(d, 0)}
+}
+
+func (c *FakeClock) NewTicker(d time.Duration) linq.Ticker {
+	return fakeTicker{clock: c, t: c.add(d, d)}
+}
+
+// Advance moves the clock forward by d and fires every timer that came due.
+// Like time.Ticker, a ticker whose previous tick was not received drops
+// the new one.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	active := c.timers[:0]
+	for _, t := range c.timers {
+		if t.when.After(c.now) {
+			active = append(active, t)
+			continue
+		}
+
+		select {
+		case t.ch <- t.when:
+		default:
+		}
+		if t.period > 0 {
+			for !t.when.After(c.now) {
+				t.when = t.when.Add(t.period)
+			}
+			active = append(active, t)
+		}
+	}
+	c.timers = active
+	c.changed.Broadcast()
+}
+
+// BlockUntil waits until at least n alarms or tickers are pending, so a test
+// can be sure a stage is waiting on the clock before calling Advance.
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.timers) < n {
+		c.changed.Wait()
+	}
+}
+
+// Pending reports how many alarms and tickers have not fired or been
+// stopped yet.
+func (c *FakeClock) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+func (c *FakeClock) add(d, period time.Duration) *fakeTimer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &fakeTimer{ch: make(chan time.Time, 1), when: c.now.Add(d), period: period}
+	c.timers = append(c.timers, t)
+	c.changed.Broadcast()
+	return t
+}
+
+// remove unschedules t, reporting whether it was still pending.
+func (c *FakeClock) remove(t *fakeTimer) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.removeLocked(t)
+}
+
+func (c *FakeClock) removeLocked(t *fakeTimer) bool {
+	defer c.changed.Broadcast()
+	for i, other := range c.timers {
+		if other == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// reset reschedules a one-shot timer d from now, discarding an undelivered
+// tick, and reports whether it was still pending.
+func (c *FakeClock) reset(t *fakeTimer, d time.Duration) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	pending := c.removeLocked(t)
+	select {
+	case <-t.ch:
+	default:
+	}
+	t.when = c.now.Add(d)
+	c.timers = append(c.timers, t)
+	c.changed.Broadcast()
+	return pending
+}
+
+type fakeTicker struct {
+	clock *FakeClock
+	t     *fakeTimer
+}
+
+func (t fakeTicker) C() <-chan time.Time { return t.t.ch }
+func (t fakeTicker) Stop()               { t.clock.remove(t.t) }
+
+type fakeAlarm struct {
+	clock *FakeClock
+	t     *fakeTimer
+}
+
+func (a fakeAlarm) C() <-chan time.Time { return a.t.ch }
+
+func (a fakeAlarm) Stop() bool {
+	pending := a.clock.remove(a.t)
+	select { // like time.Timer: no stale tick after Stop
+	case <-a.t.ch:
+	default:
+	}
+	return pending
+}
+
+func (a fakeAlarm) Reset(d time.Duration) bool { return a.clock.reset(a.t, d) }
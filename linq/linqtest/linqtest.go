@@ -0,0 +1,48 @@
+// Package linqtest provides test helpers for pipelines built with linq.
+package linqtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SaiNageswarS/go-collection-boot/linq"
+)
+
+// Timeout bounds how long the helpers wait for stage goroutines to exit.
+var Timeout = time.Second
+
+// AssertStopped fails t unless every stage goroutine feeding s exits within
+// Timeout. Call it after the stream was consumed by a sink or closed.
+func AssertStopped[T any](t testing.TB, s linq.Stream[T]) {
+	t.Helper()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.Wait()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(Timeout):
+		t.Errorf("linqtest: pipeline %q still running after %v", s.Plan(), Timeout)
+	}
+}
+
+// VerifyNoLeaks fails t if any linq stage goroutine is still running when the
+// test finishes. It counts goroutines process-wide, so do not combine it with
+// t.Parallel.
+func VerifyNoLeaks(t testing.TB) {
+	t.Helper()
+
+	t.Cleanup(func() {
+		deadline := time.Now().Add(Timeout)
+		for linq.LiveStages() > 0 {
+			if time.Now().After(deadline) {
+				t.Errorf("linqtest: %d stage goroutines leaked", linq.LiveStages())
+				return
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	})
+}
@@ -0,0 +1,89 @@
+package linqtest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/SaiNageswarS/go-collection-boot/linq"
+	"github.com/stretchr/testify/assert"
+)
+
+// recorder captures failures instead of failing the real test.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func Test_VerifyNoLeaks_ClosedPipeline(t *testing.T) {
+	VerifyNoLeaks(t)
+
+	s := linq.Where(func(n int) bool { return n > 0 })(
+		linq.FromSlice(t.Context(), []int{1, 2, 3}, linq.WithBuffer(0)),
+	)
+	<-s.C // read one item and walk away
+	s.Close()
+
+	AssertStopped(t, s)
+}
+
+func Test_AssertStopped_ReportsRunningPipeline(t *testing.T) {
+	prev := Timeout
+	Timeout = 20 * time.Millisecond
+	defer func() { Timeout = prev }()
+
+	s := linq.FromSlice(t.Context(), []int{1, 2, 3}, linq.WithBuffer(0))
+	defer s.Close()
+
+	r := &recorder{TB: t}
+	AssertStopped(r, s) // nobody reads or cancels, so the source is blocked
+
+	assert.Len(t, r.errors, 1)
+	assert.Contains(t, r.errors[0], `pipeline "FromSlice" still running`)
+}
+
+func Test_FakeClock_Pending(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	alarm := clock.NewAlarm(time.Second)
+	ticker := clock.NewTicker(time.Second)
+	assert.Equal(t, 2, clock.Pending())
+
+	clock.Advance(time.Second)
+	<-alarm.C()
+	<-ticker.C()
+	assert.Equal(t, 1, clock.Pending(), "a fired alarm is dropped, the ticker stays")
+
+	ticker.Stop()
+	assert.Zero(t, clock.Pending())
+}
+
+func Test_FakeClock_AlarmResetAndStop(t *testing.T) {
+	start := time.Unix(0, 0)
+	clock := NewFakeClock(start)
+	alarm := clock.NewAlarm(time.Second)
+
+	clock.Advance(500 * time.Millisecond)
+	assert.True(t, alarm.Reset(time.Second), "reset while pending")
+	assert.Equal(t, 1, clock.Pending(), "reset reuses the alarm")
+
+	clock.Advance(999 * time.Millisecond)
+	select {
+	case <-alarm.C():
+		t.Fatal("the reset alarm must not fire early")
+	default:
+	}
+	clock.Advance(time.Millisecond)
+	assert.Equal(t, start.Add(1500*time.Millisecond), <-alarm.C())
+
+	clock.Advance(0)
+	assert.False(t, alarm.Reset(time.Second), "already fired")
+	assert.True(t, alarm.Stop())
+	assert.False(t, alarm.Stop())
+	assert.Zero(t, clock.Pending())
+}
@@ -0,0 +1,175 @@
+package linq
+
+import (
+	"context"
+
+	"github.com/SaiNageswarS/go-collection-boot/ds"
+)
+
+// Pair is one match produced by MergeJoin.
+type Pair[L, R any] struct {
+	Left  L
+	Right R
+}
+
+// joinedCtx derives the context of a stage fed by several independent streams.
+// Once it is done, every input is cancelled with the same cause; stop
+// releases that hook when the stage finishes. The context keeps parent's
+// values but not its cancellation: the stage finishes inputs early (and so
+// cancels their contexts), and input failures arrive through recvOrFail.
+func joinedCtx(parent context.Context, inputs ...context.CancelCauseFunc) (ctx context.Context, cancel context.CancelCauseFunc, stop func() bool) {
+	ctx, cancel = context.WithCancelCause(context.WithoutCancel(parent))
+	stop = context.AfterFunc(ctx, func() {
+		for _, in := range inputs {
+			in(context.Cause(ctx))
+		}
+	})
+	return ctx, cancel, stop
+}
+
+// recvOrFail receives from s; an upstream failure is forwarded to fail.
+func recvOrFail[T any](s Stream[T], fail context.CancelCauseFunc) (T, bool) {
+	v, ok, err := tryRecv(s.ctx, s.C)
+	if err != nil {
+		fail(err)
+		return v, false
+	}
+	return v, ok
+}
+
+func mergeSortedFn[T any](less func(a, b T) bool, streams []Stream[T], opts ...StageOption) Stream[T] {
+	parent := context.Background()
+	if len(streams) > 0 {
+		parent = streams[0].ctx
+	}
+
+	capHint := 0
+	cancels := make([]context.CancelCauseFunc, len(streams))
+	waits := make([]func(), len(streams))
+	stages := make([]*Stage, len(streams))
+	for i, s := range streams {
+		capHint += s.cap
+		cancels[i], waits[i], stages[i] = s.cancel, s.wait, s.stage
+	}
+
+	ctx, cancel, stop := joinedCtx(parent, cancels...)
+	em := newEmitter[T]("MergeSorted", ctx, cancel, newStageConfig(max(1, capHint/2), opts))
+	var g stageGroup
+
+	type head struct {
+		v   T
+		src int
+	}
+
+	g.Go(func() {
+		defer close(em.out)
+		defer stop()
+
+		heads := ds.NewMinHeap(func(a, b head) bool {
+			if less(a.v, b.v) {
+				return true
+			}
+			return !less(b.v, a.v) && a.src < b.src // ties keep input order
+		})
+		for i, s := range streams {
+			v, ok := recvOrFail(s, cancel)
+			if ctx.Err() != nil {
+				return
+			}
+			if ok {
+				heads.Push(head{v, i})
+			}
+		}
+
+		for {
+			h, ok := heads.Pop()
+			if !ok { // every input is exhausted
+				return
+			}
+			if !em.send(h.v) {
+				return // downstream cancelled
+			}
+
+			v, ok := recvOrFail(streams[h.src], cancel)
+			if ctx.Err() != nil {
+				return
+			}
+			if ok {
+				heads.Push(head{v, h.src})
+			}
+		}
+	})
+
+	return newStream(ctx, em.out, cancel, capHint, g.waitFn(waits...)).
+		WithStage(em.name, TransformStage, stages...)
+}
+
+func mergeJoinFn[L, R any](left Stream[L], right Stream[R], compare func(L, R) int, opts ...StageOption) Stream[Pair[L, R]] {
+	ctx, cancel, stop := joinedCtx(left.ctx, left.cancel, right.cancel)
+	em := newEmitter[Pair[L, R]]("MergeJoin", ctx, cancel, newStageConfig(max(1, max(left.cap, right.cap)/2), opts))
+	var g stageGroup
+
+	g.Go(func() {
+		defer close(em.out)
+		defer stop()
+		defer left.cancel(ErrShortCircuit) // one side may still be producing
+		defer right.cancel(ErrShortCircuit)
+
+		l, lok := recvOrFail(left, cancel)
+		r, rok := recvOrFail(right, cancel)
+
+		for lok && rok {
+			switch c := compare(l, r); {
+			case c < 0:
+				l, lok = recvOrFail(left, cancel)
+			case c > 0:
+				r, rok = recvOrFail(right, cancel)
+			default:
+				// buffer the run of right values matching l; only this run is held
+				run := []R{r}
+				for {
+					r, rok = recvOrFail(right, cancel)
+					if !rok || compare(l, r) != 0 {
+						break
+					}
+					run = append(run, r)
+				}
+
+				for lok && compare(l, run[0]) == 0 {
+					for _, rv := range run {
+						if !em.send(Pair[L, R]{Left: l, Right: rv}) {
+							return // downstream cancelled
+						}
+					}
+					l, lok = recvOrFail(left, cancel)
+				}
+			}
+		}
+	})
+
+	return newStream(ctx, em.out, cancel, max(left.cap, right.cap), g.waitFn(left.wait, right.wait)).
+		WithStage(em.name, TransformStage, left.stage, right.stage)
+}
+
+// MergeSorted k-way merges streams that are each sorted by less into one
+// sorted stream. Only the current head of every input is held in memory.
+func MergeSorted[T any](less func(a, b T) bool, streams ...Stream[T]) Stream[T] {
+	return mergeSortedFn(less, streams)
+}
+
+// MergeSortedWith is MergeSorted with stage options (buffer, overflow):
+//
+//	linq.MergeSortedWith(less, linq.WithBuffer(64))(a, b, c)
+func MergeSortedWith[T any](less func(a, b T) bool, opts ...StageOption) func(streams ...Stream[T]) Stream[T] {
+	return func(streams ...Stream[T]) Stream[T] {
+		return mergeSortedFn(less, streams, opts...)
+	}
+}
+
+// MergeJoin inner-joins two streams sorted by the same key. compare orders a
+// left value against a right value (negative, zero, positive like cmp.Compare).
+// Matching keys produce every left × right combination; only the current run
+// of equal right values is buffered.
+func MergeJoin[L, R any](left Stream[L], right Stream[R], compare func(L, R) int, opts ...StageOption) Stream[Pair[L, R]] {
+	return mergeJoinFn(left, right, compare, opts...)
+}
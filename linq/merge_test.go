@@ -0,0 +1,124 @@
+package linq
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MergeSorted(t *testing.T) {
+	ctx := t.Context()
+	less := func(a, b int) bool { return a < b }
+
+	s := MergeSorted(less,
+		FromSlice(ctx, []int{1, 4, 7}),
+		FromSlice(ctx, []int{2, 5, 8, 9}),
+		FromSlice(ctx, []int{}),
+		FromSlice(ctx, []int{3, 6}),
+	)
+	assert.Equal(t, "(FromSlice, FromSlice, FromSlice, FromSlice) → MergeSorted", s.Plan().String())
+
+	got, err := Pipe1(s, ToSlice[int]())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}, got)
+
+	none, err := Pipe1(MergeSorted(less), ToSlice[int]())
+	assert.NoError(t, err)
+	assert.Empty(t, none)
+}
+
+func Test_MergeSorted_StableAndShortCircuit(t *testing.T) {
+	ctx := t.Context()
+
+	type rec struct {
+		ts  int
+		src string
+	}
+	byTS := func(a, b rec) bool { return a.ts < b.ts }
+
+	got, err := Pipe1(MergeSorted(byTS,
+		FromSlice(ctx, []rec{{1, "a"}, {2, "a"}}),
+		FromSlice(ctx, []rec{{1, "b"}, {2, "b"}}),
+	), ToSlice[rec]())
+	assert.NoError(t, err)
+	assert.Equal(t, []rec{{1, "a"}, {1, "b"}, {2, "a"}, {2, "b"}}, got, "ties keep input order")
+
+	s := MergeSorted(func(a, b int) bool { return a < b },
+		FromSlice(ctx, seq(100), WithBuffer(0)),
+		FromSlice(ctx, seq(100), WithBuffer(0)),
+	)
+	first, err := Pipe1(s, First[int]())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, first)
+	s.Wait() // every input must stop once the sink is done
+}
+
+func Test_MergeSorted_InputFailure(t *testing.T) {
+	ctx := t.Context()
+	boom := errors.New("boom")
+
+	fctx, fcancel := context.WithCancelCause(ctx)
+	failing := newStream(fctx, make(chan int), fcancel, 0, nil)
+	fcancel(boom)
+
+	_, err := Pipe1(MergeSorted(func(a, b int) bool { return a < b },
+		FromSlice(ctx, []int{1, 2}), failing), ToSlice[int]())
+	assert.ErrorIs(t, err, boom)
+}
+
+func Test_MergeSortedWith(t *testing.T) {
+	ctx := t.Context()
+
+	s := MergeSortedWith(func(a, b int) bool { return a < b }, WithBuffer(5))(
+		FromSlice(ctx, []int{1, 3}), FromSlice(ctx, []int{2}))
+	assert.Equal(t, 5, cap(s.C))
+
+	got, err := Pipe1(s, ToSlice[int]())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, got)
+}
+
+func Test_MergeJoin(t *testing.T) {
+	ctx := t.Context()
+
+	type order struct {
+		user int
+		item string
+	}
+	users := []int{1, 2, 2, 4, 5}
+	orders := []order{{1, "a"}, {2, "b"}, {2, "c"}, {3, "x"}, {5, "d"}}
+
+	s := MergeJoin(
+		FromSlice(ctx, users),
+		FromSlice(ctx, orders),
+		func(u int, o order) int { return cmp.Compare(u, o.user) },
+	)
+
+	got, err := Pipe1(s, ToSlice[Pair[int, order]]())
+	assert.NoError(t, err)
+	assert.Equal(t, []Pair[int, order]{
+		{1, order{1, "a"}},
+		{2, order{2, "b"}}, {2, order{2, "c"}},
+		{2, order{2, "b"}}, {2, order{2, "c"}},
+		{5, order{5, "d"}},
+	}, got)
+	s.Wait()
+}
+
+func Test_MergeJoin_StopsLongerSide(t *testing.T) {
+	ctx := t.Context()
+
+	s := MergeJoin(
+		FromSlice(ctx, []int{1}),
+		FromSlice(ctx, seq(1000), WithBuffer(0)),
+		func(a, b int) int { return cmp.Compare(a, b) },
+	)
+
+	got, err := Pipe1(s, Count[Pair[int, int]]())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, got)
+	s.Wait()
+}
@@ -0,0 +1,126 @@
+package linq
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrBufferOverflow is reported by stages using ErrorOnOverflow when the
+// downstream buffer is full.
+var ErrBufferOverflow = errors.New("linq: stage buffer overflow")
+
+// OverflowPolicy decides what a stage does when its output buffer is full.
+type OverflowPolicy int
+
+const (
+	Block           OverflowPolicy = iota // wait for downstream (default, lossless)
+	DropNewest                            // discard the value being sent
+	DropOldest                            // discard the oldest buffered value to make room
+	ErrorOnOverflow                       // fail the pipeline with ErrBufferOverflow
+)
+
+// StageOption tunes a single source or transformer.
+type StageOption func(*stageConfig)
+
+type stageConfig struct {
+	buffer   int
+	overflow OverflowPolicy
+	prefetch int
+	clock    Clock
+}
+
+// WithBuffer sets the stage's output channel capacity, overriding the
+// size derived from the upstream capacity hint. 0 means unbuffered.
+func WithBuffer(n int) StageOption {
+	return func(c *stageConfig) { c.buffer = max(0, n) }
+}
+
+// WithOverflow sets what the stage does when its output buffer is full.
+func WithOverflow(p OverflowPolicy) StageOption {
+	return func(c *stageConfig) { c.overflow = p }
+}
+
+// WithPrefetch lets paginated sources fetch up to n pages ahead of
+// downstream demand. 0 (the default) fetches a page only once the previous
+// one has been consumed.
+func WithPrefetch(n int) StageOption {
+	return func(c *stageConfig) { c.prefetch = max(0, n) }
+}
+
+// WithClock sets the clock used by time-driven stages (Interval, Timer,
+// Poll, DistinctWithin, …).
+func WithClock(c Clock) StageOption {
+	return func(cfg *stageConfig) { cfg.clock = c }
+}
+
+func newStageConfig(defaultBuffer int, opts []StageOption) stageConfig {
+	cfg := stageConfig{buffer: defaultBuffer, overflow: Block, clock: SystemClock}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.overflow != Block {
+		cfg.buffer = max(1, cfg.buffer) // dropping needs somewhere to hold values
+	}
+	return cfg
+}
+
+// emitter sends a stage's output according to its overflow policy.
+type emitter[T any] struct {
+	name   string // stage name reported in StageError
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	policy OverflowPolicy
+	out    chan T
+}
+
+func newEmitter[T any](name string, ctx context.Context, cancel context.CancelCauseFunc, cfg stageConfig) emitter[T] {
+	return emitter[T]{name: name, ctx: ctx, cancel: cancel, policy: cfg.overflow, out: make(chan T, cfg.buffer)}
+}
+
+// fail cancels the pipeline with err attributed to this stage.
+func (e emitter[T]) fail(err error) {
+	e.cancel(&StageError{Stage: e.name, Err: err})
+}
+
+// send reports false once the stage should stop producing.
+func (e emitter[T]) send(v T) bool {
+	switch e.policy {
+	case DropNewest:
+		select {
+		case <-e.ctx.Done():
+			return false
+		case e.out <- v:
+		default: // full → drop v
+		}
+		return true
+
+	case DropOldest:
+		for {
+			select {
+			case <-e.ctx.Done():
+				return false
+			case e.out <- v:
+				return true
+			default:
+			}
+			select {
+			case <-e.out: // full → discard oldest and retry
+			default:
+			}
+		}
+
+	case ErrorOnOverflow:
+		select {
+		case <-e.ctx.Done():
+			return false
+		case e.out <- v:
+			return true
+		default:
+			e.fail(ErrBufferOverflow)
+			return false
+		}
+
+	default:
+		return trySend(e.ctx, e.out, v)
+	}
+}
@@ -0,0 +1,67 @@
+package linq
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// overflowStage feeds values through a Where with the given options without
+// reading its output, so every send after the buffer fills overflows.
+// A trailing 0 is filtered out and only acts as a barrier: once the stage has
+// received it, every earlier value has been sent or dropped.
+func overflowStage(t *testing.T, values []int, opts ...StageOption) Stream[int] {
+	ctx, cancel := context.WithCancelCause(t.Context())
+	in := make(chan int) // unbuffered: a receive means the previous value was handled
+	s := Where(func(v int) bool { return v != 0 }, opts...)(newStream(ctx, in, cancel, 0, nil))
+
+	for _, v := range values {
+		in <- v
+	}
+	close(in)
+	return s
+}
+
+func Test_WithBuffer(t *testing.T) {
+	ctx := t.Context()
+
+	s := FromSlice(ctx, []int{1, 2, 3}, WithBuffer(7))
+	assert.Equal(t, 7, cap(s.C))
+
+	w := Where(func(int) bool { return true }, WithBuffer(0))(s)
+	assert.Equal(t, 0, cap(w.C), "0 means unbuffered")
+
+	got, err := Pipe1(w, ToSlice[int]())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, got)
+}
+
+func Test_Overflow_DropNewest(t *testing.T) {
+	s := overflowStage(t, []int{1, 2, 3, 4, 5, 0}, WithBuffer(2), WithOverflow(DropNewest))
+
+	got, err := Pipe1(s, ToSlice[int]())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, got, "values arriving at a full buffer are dropped")
+}
+
+func Test_Overflow_DropOldest(t *testing.T) {
+	s := overflowStage(t, []int{1, 2, 3, 4, 5, 0}, WithBuffer(2), WithOverflow(DropOldest))
+
+	got, err := Pipe1(s, ToSlice[int]())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{4, 5}, got, "oldest buffered values make room for new ones")
+}
+
+func Test_Overflow_Error(t *testing.T) {
+	s := overflowStage(t, []int{1, 2, 3}, WithBuffer(2), WithOverflow(ErrorOnOverflow))
+	<-s.ctx.Done() // the third value fails the pipeline
+
+	_, err := Pipe1(s, ToSlice[int]())
+	assert.ErrorIs(t, err, ErrBufferOverflow)
+}
+
+func Test_Overflow_NeedsBuffer(t *testing.T) {
+	cfg := newStageConfig(0, []StageOption{WithBuffer(0), WithOverflow(DropOldest)})
+	assert.Equal(t, 1, cfg.buffer, "non-blocking policies keep at least one slot")
+}
@@ -0,0 +1,96 @@
+package linq
+
+import (
+	"context"
+	"fmt"
+)
+
+// PageFetcher returns the page for token plus the token of the next page;
+// an empty next token marks the last page. The first call gets "".
+type PageFetcher[T any] func(ctx context.Context, token string) (items []T, next string, err error)
+
+// FromPages streams the items of a cursor-paginated API. By default a page
+// is fetched only once the previous one has been handed off downstream: the
+// next page is requested as soon as the last item of the current one is
+// received, so at most one page is fetched beyond what was consumed. Use
+// WithPrefetch to read further ahead. Fetching stops as soon as the pipeline
+// is cancelled, e.g. by First or Any. fetch errors fail the pipeline.
+func FromPages[T any](parent context.Context, fetch PageFetcher[T], opts ...StageOption) Stream[T] {
+	cfg := newStageConfig(0, opts)
+	if cfg.prefetch == 0 {
+		return fromPagesLazy(parent, fetch, opts)
+	}
+
+	ctx, cancel := context.WithCancelCause(parent)
+	em := newEmitter[T](fmt.Sprintf("FromPages(prefetch=%d)", cfg.prefetch), ctx, cancel, newStageConfig(1, opts))
+	// the fetcher holds one finished page while blocked on send, hence -1
+	pages := make(chan []T, cfg.prefetch-1)
+	var g stageGroup
+
+	// ── fetcher: runs up to prefetch pages ahead ──────────────
+	g.Go(func() {
+		defer close(pages)
+		token := ""
+		for {
+			items, next, err := fetch(ctx, token)
+			if err != nil {
+				em.fail(err)
+				return
+			}
+			if !trySend(ctx, pages, items) || next == "" {
+				return
+			}
+			token = next
+		}
+	})
+
+	// ── emitter: flattens pages downstream ─────────────────────
+	g.Go(func() {
+		defer close(em.out)
+		for {
+			page, ok, err := tryRecv(ctx, pages)
+			if err != nil || !ok { // cancelled or last page done
+				return
+			}
+			for _, v := range page {
+				if !em.send(v) {
+					return // downstream cancelled
+				}
+			}
+		}
+	})
+
+	return newStream(ctx, em.out, cancel, 0, g.waitFn()).
+		WithStage(em.name, SourceStage)
+}
+
+// fromPagesLazy fetches the next page only once the current one is emitted.
+// A channel cannot report a waiting receiver, so handing off the last item
+// of a page is the only demand signal available before the next fetch.
+func fromPagesLazy[T any](parent context.Context, fetch PageFetcher[T], opts []StageOption) Stream[T] {
+	var page []T
+	token, last := "", false
+
+	// unbuffered unless asked otherwise, so no item is pulled before demand
+	opts = append([]StageOption{WithBuffer(0)}, opts...)
+	return generate(parent, "FromPages", 0, opts, func(ctx context.Context) (T, bool, error) {
+		var zero T
+		for len(page) == 0 {
+			if last {
+				return zero, true, nil
+			}
+			if ctx.Err() != nil { // cancelled during a run of empty pages
+				return zero, true, nil
+			}
+			items, next, err := fetch(ctx, token)
+			if err != nil {
+				return zero, false, err
+			}
+			page, token, last = items, next, next == ""
+		}
+
+		v := page[0]
+		page = page[1:]
+		return v, false, nil
+	})
+}
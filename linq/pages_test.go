@@ -0,0 +1,95 @@
+package linq
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pagedAPI serves pages of size per page from total items and counts calls.
+type pagedAPI struct {
+	total, size int
+	calls       atomic.Int32
+	failAt      string
+}
+
+func (a *pagedAPI) fetch(ctx context.Context, token string) ([]int, string, error) {
+	a.calls.Add(1)
+	if token == a.failAt && a.failAt != "" {
+		return nil, "", errors.New("page " + token + " unavailable")
+	}
+
+	start, _ := strconv.Atoi(token)
+	end := min(start+a.size, a.total)
+	items := seq(end)[start:]
+	if end == a.total {
+		return items, "", nil
+	}
+	return items, strconv.Itoa(end), nil
+}
+
+func Test_FromPages(t *testing.T) {
+	api := &pagedAPI{total: 7, size: 3}
+
+	got, err := Pipe1(FromPages(t.Context(), api.fetch), ToSlice[int]())
+	assert.NoError(t, err)
+	assert.Equal(t, seq(7), got)
+	assert.Equal(t, int32(3), api.calls.Load(), "stops at the empty token")
+}
+
+func Test_FromPages_FirstStopsFetching(t *testing.T) {
+	api := &pagedAPI{total: 100, size: 10}
+
+	s := FromPages(t.Context(), api.fetch)
+	first, err := Pipe1(s, First[int]())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, first)
+
+	s.Wait()
+	assert.Equal(t, int32(1), api.calls.Load(), "only the first page is needed")
+}
+
+func Test_FromPages_SingleItemPages(t *testing.T) {
+	api := &pagedAPI{total: 100, size: 1}
+
+	s := FromPages(t.Context(), api.fetch)
+	first, err := Pipe1(s, First[int]())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, first)
+
+	s.Wait()
+	assert.LessOrEqual(t, api.calls.Load(), int32(2),
+		"at most one page beyond the consumed item is requested")
+}
+
+func Test_FromPages_Prefetch(t *testing.T) {
+	api := &pagedAPI{total: 100, size: 10}
+
+	s := FromPages(t.Context(), api.fetch, WithPrefetch(3))
+	assert.Equal(t, "FromPages(prefetch=3)", s.Plan().String())
+
+	assert.Equal(t, 0, <-s.C)
+	assert.Eventually(t, func() bool { return api.calls.Load() == 4 }, time.Second, time.Millisecond,
+		"current page plus three ahead")
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, int32(4), api.calls.Load(), "never more than prefetch pages ahead")
+
+	got, err := Pipe1(s, Count[int]())
+	assert.NoError(t, err)
+	assert.Equal(t, 99, got)
+}
+
+func Test_FromPages_Error(t *testing.T) {
+	for _, opts := range [][]StageOption{nil, {WithPrefetch(2)}} {
+		api := &pagedAPI{total: 100, size: 10, failAt: "20"}
+
+		got, err := Pipe1(FromPages(t.Context(), api.fetch, opts...), ToSlice[int]())
+		assert.ErrorContains(t, err, "page 20 unavailable")
+		assert.LessOrEqual(t, len(got), 20)
+	}
+}
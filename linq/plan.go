@@ -0,0 +1,190 @@
+package linq
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StageKind classifies a node of a pipeline graph.
+type StageKind int
+
+const (
+	SourceStage    StageKind = iota // produces elements (FromSlice, …)
+	TransformStage                  // consumes and produces elements (Where, Select, …)
+	SinkStage                       // terminates the pipeline (ToSlice, First, …)
+)
+
+func (k StageKind) String() string {
+	switch k {
+	case SourceStage:
+		return "source"
+	case TransformStage:
+		return "transform"
+	case SinkStage:
+		return "sink"
+	default:
+		return fmt.Sprintf("StageKind(%d)", int(k))
+	}
+}
+
+// Stage describes one step of a pipeline and the stages feeding it.
+type Stage struct {
+	Name   string
+	Kind   StageKind
+	Inputs []*Stage
+}
+
+// WithStage registers the stage that produced s. Custom sources and
+// transformers built on NewStream call it so they show up in Plan output:
+//
+//	return NewStream(in.ctx, out, in.cancel, n).WithStage("Chunk(3)", TransformStage, in.Stage())
+func (s Stream[T]) WithStage(name string, kind StageKind, inputs ...*Stage) Stream[T] {
+	st := &Stage{Name: name, Kind: kind}
+	for _, in := range inputs {
+		if in != nil { // unnamed upstream (plain NewStream) is skipped
+			st.Inputs = append(st.Inputs, in)
+		}
+	}
+	s.stage = st
+	return s
+}
+
+// Stage returns the plan node of the stage that produced s, or nil if unnamed.
+func (s Stream[T]) Stage() *Stage { return s.stage }
+
+// Plan describes the stages that lead up to s. It can be rendered before the
+// stream is handed to a sink.
+func (s Stream[T]) Plan() Plan { return Plan{root: s.stage} }
+
+// Explain wraps sink so that, once the pipeline runs, plan describes every
+// stage including the sink itself (registered under name).
+func Explain[T, R any](name string, sink func(Stream[T]) (R, error)) (func(Stream[T]) (R, error), *Plan) {
+	plan := &Plan{}
+	return func(s Stream[T]) (R, error) {
+		plan.root = s.WithStage(name, SinkStage, s.stage).stage
+		return sink(s)
+	}, plan
+}
+
+// Plan is a read-only view of a pipeline graph.
+type Plan struct {
+	root *Stage
+}
+
+// Stages lists every stage once, upstream stages before the stages they feed.
+func (p Plan) Stages() []*Stage {
+	var out []*Stage
+	seen := make(map[*Stage]struct{})
+
+	var visit func(st *Stage)
+	visit = func(st *Stage) {
+		if _, ok := seen[st]; ok {
+			return
+		}
+		seen[st] = struct{}{}
+		for _, in := range st.Inputs {
+			visit(in)
+		}
+		out = append(out, st)
+	}
+
+	if p.root != nil {
+		visit(p.root)
+	}
+	return out
+}
+
+// String renders the plan as text, e.g. "FromSlice → Where → Select".
+// Stages with several inputs list them in parentheses.
+func (p Plan) String() string {
+	if p.root == nil {
+		return ""
+	}
+
+	var render func(st *Stage) string
+	render = func(st *Stage) string {
+		switch len(st.Inputs) {
+		case 0:
+			return st.Name
+		case 1:
+			return render(st.Inputs[0]) + " → " + st.Name
+		default:
+			parts := make([]string, len(st.Inputs))
+			for i, in := range st.Inputs {
+				parts[i] = render(in)
+			}
+			return "(" + strings.Join(parts, ", ") + ") → " + st.Name
+		}
+	}
+	return render(p.root)
+}
+
+// DOT renders the plan as a Graphviz digraph.
+func (p Plan) DOT() string {
+	stages := p.Stages()
+	ids := nodeIDs(stages)
+
+	var b strings.Builder
+	b.WriteString("digraph pipeline {\n\trankdir=LR;\n")
+	for _, st := range stages {
+		fmt.Fprintf(&b, "\t%s [label=\"%s\", shape=%s];\n",
+			ids[st], strings.ReplaceAll(st.Name, `"`, `\"`), dotShape(st.Kind))
+	}
+	for _, st := range stages {
+		for _, in := range st.Inputs {
+			fmt.Fprintf(&b, "\t%s -> %s;\n", ids[in], ids[st])
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Mermaid renders the plan as a Mermaid flowchart.
+func (p Plan) Mermaid() string {
+	stages := p.Stages()
+	ids := nodeIDs(stages)
+
+	var b strings.Builder
+	b.WriteString("graph LR\n")
+	for _, st := range stages {
+		l, r := mermaidShape(st.Kind)
+		fmt.Fprintf(&b, "\t%s%s\"%s\"%s\n",
+			ids[st], l, strings.ReplaceAll(st.Name, `"`, "#quot;"), r)
+	}
+	for _, st := range stages {
+		for _, in := range st.Inputs {
+			fmt.Fprintf(&b, "\t%s --> %s\n", ids[in], ids[st])
+		}
+	}
+	return b.String()
+}
+
+func nodeIDs(stages []*Stage) map[*Stage]string {
+	ids := make(map[*Stage]string, len(stages))
+	for i, st := range stages {
+		ids[st] = fmt.Sprintf("n%d", i)
+	}
+	return ids
+}
+
+func dotShape(k StageKind) string {
+	switch k {
+	case SourceStage:
+		return "ellipse"
+	case SinkStage:
+		return "doubleoctagon"
+	default:
+		return "box"
+	}
+}
+
+func mermaidShape(k StageKind) (string, string) {
+	switch k {
+	case SourceStage:
+		return "([", "])"
+	case SinkStage:
+		return "[[", "]]"
+	default:
+		return "[", "]"
+	}
+}
@@ -0,0 +1,83 @@
+package linq
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Plan_String(t *testing.T) {
+	ctx := t.Context()
+
+	s := Select(func(n int) int { return n * n })(
+		Where(func(n int) bool { return n%2 == 0 })(
+			FromSlice(ctx, []int{1, 2, 3, 4}),
+		),
+	)
+
+	assert.Equal(t, "FromSlice → Where → Select", s.Plan().String())
+
+	got, err := Pipe1(s, ToSlice[int]())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{4, 16}, got, "describing a plan must not consume the stream")
+}
+
+func Test_Plan_Explain(t *testing.T) {
+	ctx := t.Context()
+
+	sink, plan := Explain("First", First[int]())
+	assert.Equal(t, "", plan.String(), "plan is empty until the sink runs")
+
+	first, err := Pipe2(
+		FromSlice(ctx, []int{5, 6}),
+		Distinct(func(n int) int { return n }),
+		sink,
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, first)
+	assert.Equal(t, "FromSlice → Distinct → First", plan.String())
+	assert.Len(t, plan.Stages(), 3)
+	assert.Equal(t, SinkStage, plan.Stages()[2].Kind)
+}
+
+func Test_Plan_CustomStageAndGraphs(t *testing.T) {
+	ctx := t.Context()
+
+	src := FromSlice(ctx, []int{1, 2})
+	custom := fromUnbufferedSlice(ctx, []int{3}).WithStage(`Custom("x")`, SourceStage)
+	merged := newStream(src.ctx, src.C, src.cancel, src.cap, src.wait).
+		WithStage("Merge", TransformStage, src.Stage(), custom.Stage())
+	defer custom.cancel(nil)
+	defer merged.cancel(nil)
+
+	plan := merged.Plan()
+	assert.Equal(t, `(FromSlice, Custom("x")) → Merge`, plan.String())
+
+	assert.Equal(t, "digraph pipeline {\n"+
+		"\trankdir=LR;\n"+
+		"\tn0 [label=\"FromSlice\", shape=ellipse];\n"+
+		"\tn1 [label=\"Custom(\\\"x\\\")\", shape=ellipse];\n"+
+		"\tn2 [label=\"Merge\", shape=box];\n"+
+		"\tn0 -> n2;\n"+
+		"\tn1 -> n2;\n"+
+		"}\n", plan.DOT())
+
+	assert.Equal(t, "graph LR\n"+
+		"\tn0([\"FromSlice\"])\n"+
+		"\tn1([\"Custom(#quot;x#quot;)\"])\n"+
+		"\tn2[\"Merge\"]\n"+
+		"\tn0 --> n2\n"+
+		"\tn1 --> n2\n", plan.Mermaid())
+}
+
+func Test_Plan_UnnamedStream(t *testing.T) {
+	s := fromUnbufferedSlice(t.Context(), []int{1})
+	defer s.cancel(nil)
+
+	assert.Equal(t, "", s.Plan().String())
+	assert.Empty(t, s.Plan().Stages())
+
+	named := Where(func(int) bool { return true })(s)
+	assert.Equal(t, "Where", named.Plan().String(), "unnamed upstream is skipped")
+}
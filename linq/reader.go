@@ -0,0 +1,130 @@
+package linq
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// CSVOptions configures FromCSV and FromCSVFunc. The zero value reads
+// comma-separated records without a header.
+type CSVOptions struct {
+	Comma            rune // field delimiter; ',' when zero
+	Comment          rune // lines starting with it are skipped; disabled when zero
+	Header           bool // first record is a header, not data
+	LazyQuotes       bool // see csv.Reader.LazyQuotes
+	TrimLeadingSpace bool // see csv.Reader.TrimLeadingSpace
+}
+
+func (o CSVOptions) reader(r io.Reader) *csv.Reader {
+	cr := csv.NewReader(r)
+	if o.Comma != 0 {
+		cr.Comma = o.Comma
+	}
+	cr.Comment = o.Comment
+	cr.LazyQuotes = o.LazyQuotes
+	cr.TrimLeadingSpace = o.TrimLeadingSpace
+	cr.FieldsPerRecord = -1 // ragged rows are the decoder's business
+	return cr
+}
+
+// closerOf returns r's Close method, or nil if r cannot be closed. Reader
+// sources call it to unblock a pending Read when the pipeline is cancelled.
+func closerOf(r io.Reader) func() {
+	if c, ok := r.(io.Closer); ok {
+		return func() { _ = c.Close() }
+	}
+	return nil
+}
+
+// FromLines emits r line by line, without line terminators. Read errors
+// (including lines longer than bufio.MaxScanTokenSize) fail the pipeline.
+// If r is an io.Closer, cancelling the pipeline while a read is pending
+// closes r; otherwise cancellation takes effect once the pending Read
+// returns.
+func FromLines(parent context.Context, r io.Reader, opts ...StageOption) Stream[string] {
+	sc := bufio.NewScanner(r)
+	return generateInterruptible(parent, "FromLines", 0, opts, closerOf(r), func(context.Context) (string, bool, error) {
+		if !sc.Scan() {
+			return "", true, sc.Err()
+		}
+		return sc.Text(), false, nil
+	})
+}
+
+// FromCSV emits every CSV record of r, skipping the header when opts.Header
+// is set. Parse errors fail the pipeline. Cancellation interrupts a pending
+// read as described for FromLines.
+func FromCSV(parent context.Context, r io.Reader, opts CSVOptions, stageOpts ...StageOption) Stream[[]string] {
+	return fromCSV("FromCSV", parent, r, opts, func(_, record []string) ([]string, error) {
+		return record, nil
+	}, stageOpts)
+}
+
+// FromCSVFunc decodes every CSV record of r into T. decode receives the
+// header (nil unless opts.Header is set) and the record; its errors fail
+// the pipeline, annotated with the record's line. Cancellation interrupts a
+// pending read as described for FromLines.
+func FromCSVFunc[T any](parent context.Context, r io.Reader, opts CSVOptions, decode func(header, record []string) (T, error), stageOpts ...StageOption) Stream[T] {
+	return fromCSV("FromCSVFunc", parent, r, opts, decode, stageOpts)
+}
+
+func fromCSV[T any](name string, parent context.Context, r io.Reader, opts CSVOptions, decode func(header, record []string) (T, error), stageOpts []StageOption) Stream[T] {
+	cr := opts.reader(r)
+	var header []string
+	started := false
+
+	return generateInterruptible(parent, name, 0, stageOpts, closerOf(r), func(context.Context) (T, bool, error) {
+		var zero T
+		if !started && opts.Header {
+			h, err := cr.Read()
+			if errors.Is(err, io.EOF) {
+				return zero, true, nil
+			}
+			if err != nil {
+				return zero, false, err
+			}
+			header = h
+		}
+		started = true
+
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return zero, true, nil
+		}
+		if err != nil {
+			return zero, false, err // *csv.ParseError already carries the line
+		}
+
+		v, err := decode(header, record)
+		if err != nil {
+			line, _ := cr.FieldPos(0)
+			return zero, false, fmt.Errorf("linq: csv line %d: %w", line, err)
+		}
+		return v, false, nil
+	})
+}
+
+// FromJSONLines decodes a stream of JSON values (one per line) into T.
+// Decode errors fail the pipeline, annotated with the record number.
+// Cancellation interrupts a pending read as described for FromLines.
+func FromJSONLines[T any](parent context.Context, r io.Reader, opts ...StageOption) Stream[T] {
+	dec := json.NewDecoder(r)
+	n := 0
+
+	return generateInterruptible(parent, "FromJSONLines", 0, opts, closerOf(r), func(context.Context) (T, bool, error) {
+		var v T
+		n++
+		if err := dec.Decode(&v); err != nil {
+			if errors.Is(err, io.EOF) {
+				return v, true, nil
+			}
+			return v, false, fmt.Errorf("linq: json lines record %d: %w", n, err)
+		}
+		return v, false, nil
+	})
+}
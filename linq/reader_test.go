@@ -0,0 +1,135 @@
+package linq
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FromLines(t *testing.T) {
+	ctx := t.Context()
+
+	got, err := Pipe2(
+		FromLines(ctx, strings.NewReader("alpha\nbeta\r\n\ngamma")),
+		Where(func(s string) bool { return s != "" }),
+		ToSlice[string](),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"alpha", "beta", "gamma"}, got)
+}
+
+func Test_FromLines_ReadError(t *testing.T) {
+	boom := errors.New("disk gone")
+	r := io.MultiReader(strings.NewReader("one\n"), errReader{boom})
+
+	got, err := Pipe1(FromLines(t.Context(), r), ToSlice[string]())
+	assert.ErrorIs(t, err, boom)
+	assert.LessOrEqual(t, len(got), 1)
+}
+
+func Test_FromLines_CancelStopsScanner(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	pr, pw := io.Pipe()
+	defer pw.Close()
+
+	s := FromLines(ctx, pr, WithBuffer(0))
+	go func() { _, _ = pw.Write([]byte("first\n")) }()
+	assert.Equal(t, "first", <-s.C)
+
+	cancel() // the scanner is now blocked reading a pipe nobody writes to
+	s.Wait()
+	_, err := pw.Write([]byte("late\n"))
+	assert.ErrorIs(t, err, io.ErrClosedPipe, "cancellation closes the reader")
+}
+
+func Test_FromCSV_CancelStopsReader(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+
+	s := FromCSV(t.Context(), pr, CSVOptions{}, WithBuffer(0))
+	go func() { _, _ = pw.Write([]byte("a,b\n")) }()
+	assert.Equal(t, []string{"a", "b"}, <-s.C)
+
+	s.Close() // would hang if the pending read were not interrupted
+}
+
+func Test_FromJSONLines_LeavesFinishedReaderOpen(t *testing.T) {
+	r := &closeRecorder{Reader: strings.NewReader("1\n2\n")}
+
+	got, err := Pipe1(FromJSONLines[int](t.Context(), r), ToSlice[int]())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, got)
+	assert.False(t, r.closed.Load(), "a source that reached EOF is not closed by the sink's cancel")
+}
+
+// closeRecorder is an io.ReadCloser that remembers being closed.
+type closeRecorder struct {
+	io.Reader
+	closed atomic.Bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed.Store(true)
+	return nil
+}
+
+func Test_FromCSV(t *testing.T) {
+	ctx := t.Context()
+	data := "# comment\nname;age\nann;31\nbob;42\n"
+	opts := CSVOptions{Comma: ';', Comment: '#', Header: true}
+
+	records, err := Pipe1(FromCSV(ctx, strings.NewReader(data), opts), ToSlice[[]string]())
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"ann", "31"}, {"bob", "42"}}, records)
+
+	type person struct {
+		Name string
+		Age  int
+	}
+	decode := func(header, rec []string) (person, error) {
+		assert.Equal(t, []string{"name", "age"}, header)
+		age, err := strconv.Atoi(rec[1])
+		return person{Name: rec[0], Age: age}, err
+	}
+
+	people, err := Pipe1(FromCSVFunc(ctx, strings.NewReader(data), opts, decode), ToSlice[person]())
+	assert.NoError(t, err)
+	assert.Equal(t, []person{{"ann", 31}, {"bob", 42}}, people)
+
+	_, err = Pipe1(FromCSVFunc(ctx, strings.NewReader(data+"cy;old\n"), opts, decode), ToSlice[person]())
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+	assert.ErrorContains(t, err, "csv line 5")
+
+	var perr *csv.ParseError
+	_, err = Pipe1(FromCSV(ctx, strings.NewReader("a,\"b\n"), CSVOptions{}), ToSlice[[]string]())
+	assert.ErrorAs(t, err, &perr)
+}
+
+func Test_FromJSONLines(t *testing.T) {
+	ctx := t.Context()
+
+	type event struct {
+		ID   int    `json:"id"`
+		Kind string `json:"kind"`
+	}
+	data := `{"id":1,"kind":"a"}
+{"id":2,"kind":"b"}
+`
+	got, err := Pipe1(FromJSONLines[event](ctx, strings.NewReader(data)), ToSlice[event]())
+	assert.NoError(t, err)
+	assert.Equal(t, []event{{1, "a"}, {2, "b"}}, got)
+
+	_, err = Pipe1(FromJSONLines[event](ctx, strings.NewReader(data+"{oops}\n")), ToSlice[event]())
+	assert.ErrorContains(t, err, "json lines record 3")
+}
+
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
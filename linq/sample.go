@@ -0,0 +1,117 @@
+package linq
+
+import (
+	"fmt"
+	"math/rand/v2"
+)
+
+// rngOrDefault returns rng, or a generator backed by the global source when nil.
+func rngOrDefault(rng *rand.Rand) *rand.Rand {
+	if rng == nil {
+		return rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+	return rng
+}
+
+// sampleRateFn keeps each value independently with probability p.
+func sampleRateFn[T any](in Stream[T], p float64, rng *rand.Rand, opts ...StageOption) Stream[T] {
+	em := newEmitter[T](fmt.Sprintf("SampleRate(%g)", p), in.ctx, in.cancel, newStageConfig(max(1, in.cap/2), opts))
+	var g stageGroup
+	rng = rngOrDefault(rng)
+
+	g.Go(func() {
+		defer close(em.out)
+		for {
+			v, ok, err := tryRecv(in.ctx, in.C)
+			if err != nil || !ok { // cancelled or upstream closed
+				return
+			}
+
+			if rng.Float64() < p {
+				if !em.send(v) {
+					return // downstream cancelled
+				}
+			}
+		}
+	})
+
+	return newStream(in.ctx, em.out, in.cancel, in.cap, g.waitFn(in.wait)).
+		WithStage(em.name, TransformStage, in.stage)
+}
+
+// everyNthFn keeps the n-th, 2n-th, 3n-th, … values.
+func everyNthFn[T any](in Stream[T], n int, opts ...StageOption) Stream[T] {
+	n = max(1, n)
+	em := newEmitter[T](fmt.Sprintf("EveryNth(%d)", n), in.ctx, in.cancel, newStageConfig(max(1, in.cap/2), opts))
+	var g stageGroup
+
+	g.Go(func() {
+		defer close(em.out)
+		seen := 0
+		for {
+			v, ok, err := tryRecv(in.ctx, in.C)
+			if err != nil || !ok { // cancelled or upstream closed
+				return
+			}
+
+			seen++
+			if seen == n {
+				seen = 0
+				if !em.send(v) {
+					return // downstream cancelled
+				}
+			}
+		}
+	})
+
+	return newStream(in.ctx, em.out, in.cancel, in.cap/n, g.waitFn(in.wait)).
+		WithStage(em.name, TransformStage, in.stage)
+}
+
+// reservoirSampleFn keeps a uniform sample of k values (Algorithm R).
+func reservoirSampleFn[T any](s Stream[T], k int, rng *rand.Rand) ([]T, error) {
+	defer s.cancel(ErrShortCircuit) // close upstream transformers
+
+	rng = rngOrDefault(rng)
+	out := make([]T, 0, max(0, k))
+
+	for i := 0; ; i++ {
+		v, ok, err := tryRecv(s.ctx, s.C)
+		if err != nil { // context cancelled
+			return nil, err
+		}
+		if !ok { // channel closed
+			return out, nil
+		}
+
+		if i < k {
+			out = append(out, v)
+		} else if j := rng.IntN(i + 1); j < k {
+			out[j] = v
+		}
+	}
+}
+
+// SampleRate keeps each value with probability p. Pass a seeded rng
+// (e.g. rand.New(rand.NewPCG(1, 2))) for reproducible output; nil uses a
+// randomly seeded one.
+func SampleRate[T any](p float64, rng *rand.Rand, opts ...StageOption) func(Stream[T]) Stream[T] {
+	return func(in Stream[T]) Stream[T] {
+		return sampleRateFn(in, p, rng, opts...)
+	}
+}
+
+// EveryNth keeps every n-th value, starting with the n-th.
+func EveryNth[T any](n int, opts ...StageOption) func(Stream[T]) Stream[T] {
+	return func(in Stream[T]) Stream[T] {
+		return everyNthFn(in, n, opts...)
+	}
+}
+
+// ReservoirSample returns a uniform random sample of up to k values without
+// holding the whole stream in memory. The sample is not in stream order.
+func ReservoirSample[T any](k int, rng *rand.Rand) func(Stream[T]) ([]T, error) {
+	return func(s Stream[T]) ([]T, error) {
+		return reservoirSampleFn(s, k, rng)
+	}
+}
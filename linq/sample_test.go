@@ -0,0 +1,72 @@
+package linq
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func seq(n int) []int {
+	out := make([]int, n)
+	for i := range out {
+		out[i] = i
+	}
+	return out
+}
+
+func Test_EveryNth(t *testing.T) {
+	ctx := t.Context()
+
+	got, err := Pipe2(
+		FromSlice(ctx, seq(10)),
+		EveryNth[int](3),
+		ToSlice[int](),
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 5, 8}, got)
+}
+
+func Test_SampleRate_Reproducible(t *testing.T) {
+	ctx := t.Context()
+
+	run := func() []int {
+		got, err := Pipe2(
+			FromSlice(ctx, seq(1000)),
+			SampleRate[int](0.1, rand.New(rand.NewPCG(1, 2))),
+			ToSlice[int](),
+		)
+		assert.NoError(t, err)
+		return got
+	}
+
+	first := run()
+	assert.Equal(t, first, run(), "same seed must give the same sample")
+	assert.InDelta(t, 100, len(first), 40)
+
+	all, err := Pipe2(FromSlice(ctx, seq(5)), SampleRate[int](1, nil), Count[int]())
+	assert.NoError(t, err)
+	assert.Equal(t, 5, all)
+}
+
+func Test_ReservoirSample(t *testing.T) {
+	ctx := t.Context()
+
+	sample, err := Pipe1(
+		FromSlice(ctx, seq(1000)),
+		ReservoirSample[int](10, rand.New(rand.NewPCG(7, 7))),
+	)
+	assert.NoError(t, err)
+	assert.Len(t, sample, 10)
+	for _, v := range sample {
+		assert.True(t, v >= 0 && v < 1000)
+	}
+
+	again, _ := Pipe1(FromSlice(ctx, seq(1000)), ReservoirSample[int](10, rand.New(rand.NewPCG(7, 7))))
+	assert.Equal(t, sample, again, "same seed must give the same sample")
+
+	short, err := Pipe1(FromSlice(ctx, seq(3)), ReservoirSample[int](10, nil))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2}, short, "fewer than k values are all kept")
+}
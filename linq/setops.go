@@ -0,0 +1,137 @@
+package linq
+
+import "context"
+
+// bindOther ties other's lifetime to in: cancelling the combined pipeline
+// (or in's parent context) cancels other as well. The returned stop func
+// releases the binding.
+func bindOther[T, U any](in Stream[T], other Stream[U]) (cancel context.CancelCauseFunc, stop func() bool) {
+	stop = context.AfterFunc(in.ctx, func() { other.cancel(context.Cause(in.ctx)) })
+	cancel = func(cause error) {
+		in.cancel(cause)
+		other.cancel(cause)
+	}
+	return cancel, stop
+}
+
+// collectKeys drains other into a key set, reporting any upstream failure.
+func collectKeys[T any, K comparable](other Stream[T], keySelector func(T) K) (map[K]struct{}, error) {
+	keys := make(map[K]struct{}, other.cap)
+	for {
+		v, ok, err := tryRecv(other.ctx, other.C)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return keys, nil
+		}
+		keys[keySelector(v)] = struct{}{}
+	}
+}
+
+// unionByFn emits the distinct values of in followed by the values of other
+// whose keys were not seen yet.
+func unionByFn[T any, K comparable](in, other Stream[T], keySelector func(T) K, opts ...StageOption) Stream[T] {
+	cancel, stop := bindOther(in, other)
+	em := newEmitter[T]("UnionBy", in.ctx, cancel, newStageConfig(max(1, (in.cap+other.cap)/2), opts))
+	var g stageGroup
+
+	g.Go(func() {
+		defer close(em.out)
+		defer other.cancel(ErrShortCircuit)
+		defer stop()
+		seen := make(map[K]struct{})
+
+		for _, src := range []Stream[T]{in, other} {
+			for {
+				v, ok, err := tryRecv(src.ctx, src.C)
+				if err != nil {
+					cancel(err)
+					return
+				}
+				if !ok { // this side is exhausted, continue with the next
+					break
+				}
+
+				key := keySelector(v)
+				if _, dup := seen[key]; dup {
+					continue
+				}
+				seen[key] = struct{}{}
+				if !em.send(v) {
+					return // downstream cancelled
+				}
+			}
+		}
+	})
+
+	return newStream(in.ctx, em.out, cancel, in.cap+other.cap, g.waitFn(in.wait, other.wait)).
+		WithStage(em.name, TransformStage, in.stage, other.stage)
+}
+
+// filterByKeysFn collects other's keys, then streams the distinct values of
+// in whose key presence in that set equals keep.
+func filterByKeysFn[T any, K comparable](name string, in, other Stream[T], keySelector func(T) K, keep bool, opts ...StageOption) Stream[T] {
+	cancel, stop := bindOther(in, other)
+	em := newEmitter[T](name, in.ctx, cancel, newStageConfig(max(1, in.cap/2), opts))
+	var g stageGroup
+
+	g.Go(func() {
+		defer close(em.out)
+		defer other.cancel(ErrShortCircuit)
+		defer stop()
+
+		keys, err := collectKeys(other, keySelector)
+		if err != nil {
+			cancel(err)
+			return
+		}
+		emitted := make(map[K]struct{})
+
+		for {
+			v, ok, err := tryRecv(in.ctx, in.C)
+			if err != nil || !ok { // cancelled or upstream closed
+				return
+			}
+
+			key := keySelector(v)
+			if _, found := keys[key]; found != keep {
+				continue
+			}
+			if _, dup := emitted[key]; dup {
+				continue
+			}
+			emitted[key] = struct{}{}
+			if !em.send(v) {
+				return // downstream cancelled
+			}
+		}
+	})
+
+	return newStream(in.ctx, em.out, cancel, in.cap, g.waitFn(in.wait, other.wait)).
+		WithStage(name, TransformStage, in.stage, other.stage)
+}
+
+// UnionBy emits the values of the input stream, then those of other, skipping
+// any value whose key was already emitted. Order is first-seen order.
+func UnionBy[T any, K comparable](other Stream[T], keySelector func(T) K, opts ...StageOption) func(Stream[T]) Stream[T] {
+	return func(in Stream[T]) Stream[T] {
+		return unionByFn(in, other, keySelector, opts...)
+	}
+}
+
+// IntersectBy emits the distinct values of the input stream whose key also
+// occurs in other. other is read fully before the input is streamed.
+func IntersectBy[T any, K comparable](other Stream[T], keySelector func(T) K, opts ...StageOption) func(Stream[T]) Stream[T] {
+	return func(in Stream[T]) Stream[T] {
+		return filterByKeysFn("IntersectBy", in, other, keySelector, true, opts...)
+	}
+}
+
+// ExceptBy emits the distinct values of the input stream whose key does not
+// occur in other. other is read fully before the input is streamed.
+func ExceptBy[T any, K comparable](other Stream[T], keySelector func(T) K, opts ...StageOption) func(Stream[T]) Stream[T] {
+	return func(in Stream[T]) Stream[T] {
+		return filterByKeysFn("ExceptBy", in, other, keySelector, false, opts...)
+	}
+}
@@ -0,0 +1,77 @@
+package linq
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UnionBy(t *testing.T) {
+	ctx := t.Context()
+
+	s := UnionBy(FromSlice(ctx, []int{3, 4, 5, 4}), identity[int])(FromSlice(ctx, []int{1, 2, 3, 1}))
+	assert.Equal(t, "(FromSlice, FromSlice) → UnionBy", s.Plan().String())
+
+	got, err := Pipe1(s, ToSlice[int]())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, got)
+}
+
+func Test_IntersectBy(t *testing.T) {
+	ctx := t.Context()
+
+	type user struct {
+		ID   int
+		Name string
+	}
+	users := []user{{1, "ann"}, {2, "bob"}, {3, "cy"}, {1, "ann again"}}
+	active := []user{{3, ""}, {1, ""}, {9, ""}}
+
+	got, err := Pipe2(
+		FromSlice(ctx, users),
+		IntersectBy(FromSlice(ctx, active), func(u user) int { return u.ID }),
+		ToSlice[user](),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []user{{1, "ann"}, {3, "cy"}}, got, "outer order, first occurrence wins")
+}
+
+func Test_ExceptBy(t *testing.T) {
+	ctx := t.Context()
+
+	got, err := Pipe2(
+		FromSlice(ctx, []string{"a", "b", "c", "b", "d"}),
+		ExceptBy(FromSlice(ctx, []string{"c"}), identity[string]),
+		ToSlice[string](),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "d"}, got)
+}
+
+func Test_SetOps_ShortCircuitStopsBothSides(t *testing.T) {
+	ctx := t.Context()
+
+	left := FromSlice(ctx, seq(100), WithBuffer(0))
+	right := FromSlice(ctx, seq(100), WithBuffer(0))
+	s := UnionBy(right, identity[int])(left)
+
+	first, err := Pipe1(s, First[int]())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, first)
+
+	s.Wait() // both sources must observe the cancellation
+}
+
+func Test_SetOps_InnerFailurePropagates(t *testing.T) {
+	ctx := t.Context()
+	boom := errors.New("boom")
+
+	ictx, icancel := context.WithCancelCause(ctx)
+	inner := newStream(ictx, make(chan int), icancel, 0, nil)
+	icancel(boom)
+
+	_, err := Pipe2(FromSlice(ctx, []int{1}), ExceptBy(inner, identity[int]), ToSlice[int]())
+	assert.ErrorIs(t, err, boom)
+}
@@ -0,0 +1,75 @@
+package linq
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// fromTicks starts a source that calls fn on every tick of the stage clock
+// (or only once if once is set) and emits its results.
+func fromTicks[T any](parent context.Context, name string, d time.Duration, once bool, opts []StageOption, fn func(ctx context.Context, tick time.Time) (T, error)) Stream[T] {
+	if !once && d <= 0 { // panic here, not in the stage goroutine where nobody can recover
+		panic(fmt.Sprintf("linq: non-positive interval for %s: %v", name, d))
+	}
+	ctx, cancel := context.WithCancelCause(parent)
+	cfg := newStageConfig(1, opts)
+	em := newEmitter[T](fmt.Sprintf("%s(%v)", name, d), ctx, cancel, cfg)
+	var g stageGroup
+
+	g.Go(func() {
+		defer close(em.out)
+
+		var ticks <-chan time.Time
+		if once {
+			a := cfg.clock.NewAlarm(d)
+			defer a.Stop()
+			ticks = a.C()
+		} else {
+			t := cfg.clock.NewTicker(d)
+			defer t.Stop()
+			ticks = t.C()
+		}
+
+		for {
+			tick, ok, err := tryRecv(ctx, ticks)
+			if err != nil || !ok { // cancelled
+				return
+			}
+
+			v, err := fn(ctx, tick)
+			if err != nil {
+				em.fail(err)
+				return
+			}
+			if !em.send(v) || once {
+				return
+			}
+		}
+	})
+
+	return newStream(ctx, em.out, cancel, 1, g.waitFn()).
+		WithStage(em.name, SourceStage)
+}
+
+func tickTime(_ context.Context, tick time.Time) (time.Time, error) { return tick, nil }
+
+// Interval emits the tick time every d until the pipeline is cancelled.
+// Like time.Ticker, ticks are dropped while downstream is busy, and a
+// non-positive d panics.
+func Interval(parent context.Context, d time.Duration, opts ...StageOption) Stream[time.Time] {
+	return fromTicks(parent, "Interval", d, false, opts, tickTime)
+}
+
+// Timer emits a single value after d and then ends.
+func Timer(parent context.Context, d time.Duration, opts ...StageOption) Stream[time.Time] {
+	return fromTicks(parent, "Timer", d, true, opts, tickTime)
+}
+
+// Poll calls fn every d and emits its results until the pipeline is
+// cancelled. An error from fn fails the pipeline; a non-positive d panics.
+func Poll[T any](parent context.Context, d time.Duration, fn func(ctx context.Context) (T, error), opts ...StageOption) Stream[T] {
+	return fromTicks(parent, "Poll", d, false, opts, func(ctx context.Context, _ time.Time) (T, error) {
+		return fn(ctx)
+	})
+}
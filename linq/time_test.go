@@ -0,0 +1,146 @@
+// External test package: linqtest imports linq.
+package linq_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/SaiNageswarS/go-collection-boot/linq"
+	"github.com/SaiNageswarS/go-collection-boot/linq/linqtest"
+	"github.com/stretchr/testify/assert"
+)
+
+var epoch = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func Test_Interval(t *testing.T) {
+	clock := linqtest.NewFakeClock(epoch)
+	s := linq.Interval(t.Context(), time.Second, linq.WithClock(clock))
+	assert.Equal(t, "Interval(1s)", s.Plan().String())
+
+	clock.BlockUntil(1)
+	for i := 1; i <= 3; i++ {
+		clock.Advance(time.Second)
+		assert.Equal(t, epoch.Add(time.Duration(i)*time.Second), <-s.C)
+	}
+
+	s.Close()
+	assert.Zero(t, clock.Pending(), "Close stops the ticker")
+}
+
+func Test_Interval_NonPositivePanics(t *testing.T) {
+	assert.PanicsWithValue(t, "linq: non-positive interval for Interval: 0s", func() {
+		linq.Interval(t.Context(), 0)
+	})
+	assert.Panics(t, func() {
+		linq.Poll(t.Context(), -time.Second, func(context.Context) (int, error) { return 0, nil })
+	})
+}
+
+func Test_Timer(t *testing.T) {
+	clock := linqtest.NewFakeClock(epoch)
+	s := linq.Timer(t.Context(), time.Minute, linq.WithClock(clock))
+
+	clock.BlockUntil(1)
+	clock.Advance(30 * time.Second)
+	clock.Advance(30 * time.Second)
+
+	got, err := linq.Pipe1(s, linq.ToSlice[time.Time]())
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Time{epoch.Add(time.Minute)}, got)
+}
+
+func Test_Poll(t *testing.T) {
+	clock := linqtest.NewFakeClock(epoch)
+	calls := 0
+	boom := errors.New("backend down")
+
+	s := linq.Poll(t.Context(), time.Second, func(ctx context.Context) (int, error) {
+		calls++
+		if calls == 3 {
+			return 0, boom
+		}
+		return calls * 10, nil
+	}, linq.WithClock(clock), linq.WithBuffer(0))
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+	assert.Equal(t, 10, <-s.C)
+	clock.Advance(time.Second)
+	assert.Equal(t, 20, <-s.C)
+	clock.Advance(time.Second)
+
+	_, err := linq.Pipe1(s, linq.ToSlice[int]())
+	assert.ErrorIs(t, err, boom)
+}
+
+func Test_DistinctWithin_FakeClock(t *testing.T) {
+	clock := linqtest.NewFakeClock(epoch)
+	ctx, cancel := context.WithCancel(t.Context())
+	in := make(chan string)
+	s := linq.DistinctWithin(func(s string) string { return s }, time.Minute, linq.WithClock(clock))(
+		linq.NewStream(ctx, in, cancel, 0),
+	)
+
+	go func() {
+		defer close(in)
+		in <- "a"
+		in <- "a"
+		in <- "b" // barrier: "a" was handled
+		clock.Advance(time.Minute)
+		in <- "a"
+	}()
+
+	got, err := linq.Pipe1(s, linq.ToSlice[string]())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "a"}, got)
+}
+
+func Test_WithTimeout(t *testing.T) {
+	got, err := linq.Pipe2(
+		linq.FromSlice(t.Context(), []int{1, 2, 3}),
+		linq.WithTimeout[int](time.Second, linq.WithClock(linqtest.NewFakeClock(epoch))),
+		linq.ToSlice[int](),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, got)
+
+	clock := linqtest.NewFakeClock(epoch)
+	in := make(chan int)
+	src := linq.FromPages(t.Context(), func(ctx context.Context, _ string) ([]int, string, error) {
+		select {
+		case v := <-in:
+			return []int{v}, "more", nil
+		case <-ctx.Done():
+			return nil, "", ctx.Err()
+		}
+	})
+	s := linq.WithTimeout[int](time.Second, linq.WithClock(clock), linq.WithBuffer(0))(src)
+
+	clock.BlockUntil(1)
+	for i := 1; i <= 100; i++ {
+		in <- i
+		assert.Equal(t, i, <-s.C)
+		clock.Advance(999 * time.Millisecond) // always just in time
+	}
+	assert.Equal(t, 1, clock.Pending(), "one alarm, reset per element")
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := linq.Pipe1(s, linq.ToSlice[int]())
+		errs <- err
+	}()
+	// then silence; the alarm is reset only once the last element is passed
+	// on, so keep advancing until it fires
+	for err := error(nil); err == nil; {
+		clock.Advance(time.Second)
+		select {
+		case err = <-errs:
+			assert.ErrorIs(t, err, linq.ErrTimeout)
+		default:
+			runtime.Gosched()
+		}
+	}
+}
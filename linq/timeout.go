@@ -0,0 +1,99 @@
+package linq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrTimeout is reported when SelectTimeout or WithTimeout gives up waiting.
+var ErrTimeout = errors.New("linq: timeout")
+
+// selectTimeoutFn maps every element with its own deadline derived from the
+// stream context.
+func selectTimeoutFn[T, U any](in Stream[T], d time.Duration, f func(context.Context, T) (U, error), opts ...StageOption) Stream[U] {
+	em := newEmitter[U](fmt.Sprintf("SelectTimeout(%v)", d), in.ctx, in.cancel, newStageConfig(max(1, in.cap/2), opts))
+	var g stageGroup
+
+	g.Go(func() {
+		defer close(em.out)
+		for {
+			v, ok, err := tryRecv(in.ctx, in.C)
+			if err != nil || !ok { // cancelled or upstream closed
+				return
+			}
+
+			ectx, cancel := context.WithTimeout(in.ctx, d)
+			u, err := f(ectx, v)
+			timedOut := errors.Is(ectx.Err(), context.DeadlineExceeded) && in.ctx.Err() == nil
+			cancel()
+
+			if err != nil {
+				if timedOut {
+					err = fmt.Errorf("%w: element exceeded %v: %w", ErrTimeout, d, err)
+				}
+				em.fail(err)
+				return
+			}
+			if !em.send(u) {
+				return // downstream cancelled
+			}
+		}
+	})
+
+	return newStream(in.ctx, em.out, in.cancel, in.cap, g.waitFn(in.wait)).
+		WithStage(em.name, TransformStage, in.stage)
+}
+
+// idleTimeoutFn fails the pipeline if upstream is silent for longer than d.
+func idleTimeoutFn[T any](in Stream[T], d time.Duration, opts ...StageOption) Stream[T] {
+	cfg := newStageConfig(max(1, in.cap/2), opts)
+	em := newEmitter[T](fmt.Sprintf("WithTimeout(%v)", d), in.ctx, in.cancel, cfg)
+	var g stageGroup
+
+	g.Go(func() {
+		defer close(em.out)
+		idle := cfg.clock.NewAlarm(d)
+		defer idle.Stop()
+
+		for {
+			select {
+			case <-in.ctx.Done():
+				return
+			case <-idle.C():
+				em.fail(fmt.Errorf("%w: no element within %v", ErrTimeout, d))
+				return
+			case v, ok := <-in.C:
+				if !ok { // channel closed
+					return
+				}
+				if !em.send(v) {
+					return // downstream cancelled
+				}
+				idle.Reset(d) // time spent blocked on downstream doesn't count
+			}
+		}
+	})
+
+	return newStream(in.ctx, em.out, in.cancel, in.cap, g.waitFn(in.wait)).
+		WithStage(em.name, TransformStage, in.stage)
+}
+
+// SelectTimeout maps T → U, giving every call of f its own deadline d derived
+// from the pipeline context. If f fails the pipeline fails with its error;
+// when the deadline was the reason, the error also matches ErrTimeout.
+func SelectTimeout[T, U any](d time.Duration, f func(context.Context, T) (U, error), opts ...StageOption) func(Stream[T]) Stream[U] {
+	return func(in Stream[T]) Stream[U] {
+		return selectTimeoutFn(in, d, f, opts...)
+	}
+}
+
+// WithTimeout passes values through unchanged but fails the pipeline with
+// ErrTimeout if no value arrives within d of the previous one being passed
+// on (or of the start). Time is read from the stage's clock (see WithClock).
+func WithTimeout[T any](d time.Duration, opts ...StageOption) func(Stream[T]) Stream[T] {
+	return func(in Stream[T]) Stream[T] {
+		return idleTimeoutFn(in, d, opts...)
+	}
+}
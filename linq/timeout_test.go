@@ -0,0 +1,49 @@
+package linq
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SelectTimeout(t *testing.T) {
+	ctx := t.Context()
+	slowOnThree := func(ctx context.Context, n int) (int, error) {
+		if n == 3 {
+			<-ctx.Done() // a well-behaved call gives up with its context
+			return 0, ctx.Err()
+		}
+		return n * 10, nil
+	}
+
+	got, err := Pipe2(
+		FromSlice(ctx, []int{1, 2}),
+		SelectTimeout(time.Second, slowOnThree),
+		ToSlice[int](),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{10, 20}, got)
+
+	_, err = Pipe2(
+		FromSlice(ctx, []int{1, 2, 3, 4}),
+		SelectTimeout(10*time.Millisecond, slowOnThree),
+		ToSlice[int](),
+	)
+	assert.ErrorIs(t, err, ErrTimeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func Test_SelectTimeout_FunctionError(t *testing.T) {
+	boom := errors.New("boom")
+
+	_, err := Pipe2(
+		FromSlice(t.Context(), []int{1}),
+		SelectTimeout(time.Second, func(context.Context, int) (int, error) { return 0, boom }),
+		ToSlice[int](),
+	)
+	assert.ErrorIs(t, err, boom)
+	assert.NotErrorIs(t, err, ErrTimeout, "only deadline failures are timeouts")
+}
@@ -0,0 +1,74 @@
+package linq
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+)
+
+// writeAllFn feeds every element to write until the stream ends, the
+// context is cancelled or write fails; flush always runs. It returns how
+// many elements were written.
+func writeAllFn[T any](s Stream[T], write func(T) error, flush func() error) (n int, err error) {
+	defer s.cancel(ErrShortCircuit) // close upstream transformers
+	defer func() {
+		if ferr := flush(); err == nil {
+			err = ferr
+		}
+	}()
+
+	for {
+		v, ok, err := tryRecv(s.ctx, s.C)
+		if err != nil { // context cancelled
+			return n, err
+		}
+		if !ok { // channel closed
+			return n, nil
+		}
+		if err := write(v); err != nil {
+			return n, err
+		}
+		n++
+	}
+}
+
+// ToWriterLines writes format(v) followed by a newline for every element.
+func ToWriterLines[T any](w io.Writer, format func(T) string) func(Stream[T]) (int, error) {
+	return func(s Stream[T]) (int, error) {
+		bw := bufio.NewWriter(w)
+		return writeAllFn(s, func(v T) error {
+			_, err := bw.WriteString(format(v) + "\n")
+			return err
+		}, bw.Flush)
+	}
+}
+
+// ToCSV writes headers (skipped when nil) and then rowFn(v) as one CSV
+// record per element. The returned count excludes the header.
+func ToCSV[T any](w io.Writer, headers []string, rowFn func(T) []string) func(Stream[T]) (int, error) {
+	return func(s Stream[T]) (int, error) {
+		cw := csv.NewWriter(w)
+		flush := func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+
+		if headers != nil {
+			if err := cw.Write(headers); err != nil {
+				s.cancel(ErrShortCircuit)
+				return 0, err
+			}
+		}
+		return writeAllFn(s, func(v T) error { return cw.Write(rowFn(v)) }, flush)
+	}
+}
+
+// ToJSONLines writes every element as one line of JSON.
+func ToJSONLines[T any](w io.Writer) func(Stream[T]) (int, error) {
+	return func(s Stream[T]) (int, error) {
+		bw := bufio.NewWriter(w)
+		enc := json.NewEncoder(bw)
+		return writeAllFn(s, func(v T) error { return enc.Encode(v) }, bw.Flush)
+	}
+}
@@ -0,0 +1,73 @@
+package linq
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ToWriterLines(t *testing.T) {
+	var b strings.Builder
+
+	n, err := Pipe1(FromSlice(t.Context(), []int{1, 2, 3}), ToWriterLines(&b, strconv.Itoa))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, "1\n2\n3\n", b.String())
+}
+
+func Test_ToCSV(t *testing.T) {
+	type person struct {
+		Name string
+		Age  int
+	}
+	var b strings.Builder
+
+	n, err := Pipe1(
+		FromSlice(t.Context(), []person{{"ann", 31}, {"bob, jr", 42}}),
+		ToCSV(&b, []string{"name", "age"}, func(p person) []string {
+			return []string{p.Name, strconv.Itoa(p.Age)}
+		}),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n, "header is not counted")
+	assert.Equal(t, "name,age\nann,31\n\"bob, jr\",42\n", b.String())
+}
+
+func Test_ToJSONLines_RoundTrip(t *testing.T) {
+	type event struct {
+		ID int `json:"id"`
+	}
+	ctx := t.Context()
+	var b strings.Builder
+
+	n, err := Pipe1(FromSlice(ctx, []event{{1}, {2}}), ToJSONLines[event](&b))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, "{\"id\":1}\n{\"id\":2}\n", b.String())
+
+	back, err := Pipe1(FromJSONLines[event](ctx, strings.NewReader(b.String())), ToSlice[event]())
+	assert.NoError(t, err)
+	assert.Equal(t, []event{{1}, {2}}, back)
+}
+
+func Test_ToWriter_Errors(t *testing.T) {
+	boom := errors.New("disk full")
+
+	n, err := Pipe1(FromSlice(t.Context(), seq(10_000)), ToWriterLines(failingWriter{boom}, strconv.Itoa))
+	assert.ErrorIs(t, err, boom)
+	assert.Less(t, n, 10_000)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	var b strings.Builder
+	_, err = Pipe1(FromSlice(ctx, []int{1}), ToJSONLines[int](&b))
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+type failingWriter struct{ err error }
+
+func (w failingWriter) Write([]byte) (int, error) { return 0, w.err }